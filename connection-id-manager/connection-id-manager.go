@@ -0,0 +1,363 @@
+package ConnectionIDManager
+
+import (
+	"errors"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+const (
+	// MaxIssuedConnectionIDs is the number of active connection IDs a Manager issues even if the peer accepts more.
+	MaxIssuedConnectionIDs varint.Int62 = 8
+	// MaxPendingRetireConnectionIDs is the number of RETIRE_CONNECTION_ID frames a Manager queues before the peer is considered to
+	// retire connection IDs faster than they can be acknowledged.
+	MaxPendingRetireConnectionIDs int = 32
+)
+
+var (
+	// NoPeerConnectionID is returned when every connection ID issued by the peer is retired.
+	NoPeerConnectionID error = errors.New("No unused connection ID issued by the peer")
+)
+
+// LocalConnectionID is a connection ID issued to the peer.
+type LocalConnectionID struct {
+	SequenceNumber      varint.Int62
+	ConnectionID        ConnectionIdentifier.ConnectionID
+	StatelessResetToken ConnectionIdentifier.StatelessResetToken
+}
+
+// PeerConnectionID is a connection ID issued by the peer.
+type PeerConnectionID struct {
+	SequenceNumber varint.Int62
+	ConnectionID   ConnectionIdentifier.ConnectionID
+	// HasStatelessResetToken is false for the connection ID of the handshake of a client, which has no stateless reset token.
+	HasStatelessResetToken bool
+	StatelessResetToken    ConnectionIdentifier.StatelessResetToken
+}
+
+// Manager issues local connection IDs and tracks the connection IDs issued by the peer.
+// NEW_CONNECTION_ID and RETIRE_CONNECTION_ID frames that must be sent are queued as work items for the packetizer.
+//
+// Manager is not safe for concurrent use.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-5.1
+type Manager struct {
+	generator         ConnectionIdentifier.ConnectionIDGenerator
+	statelessResetKey []byte
+	// zeroLengthPeerConnectionID is true when the endpoint sends packets with a zero-length Destination Connection ID.
+	zeroLengthPeerConnectionID bool
+
+	local                  []LocalConnectionID
+	nextSequenceNumber     varint.Int62
+	localRetirePriorTo     varint.Int62
+	peerActiveLimit        varint.Int62
+	pendingNewConnectionID []varint.Int62
+
+	// peer[0] is the connection ID in use.
+	peer                      []PeerConnectionID
+	peerRetirePriorTo         varint.Int62
+	localActiveLimit          varint.Int62
+	pendingRetireConnectionID []varint.Int62
+	// retiredPeer holds the sequence numbers of retired peer connection IDs at or above the peerRetirePriorTo,
+	// so a retransmitted NEW_CONNECTION_ID frame doesn't add them again.
+	retiredPeer map[varint.Int62]bool
+}
+
+// NewManager returns a Manager of a connection that issues connection IDs generated by the generator.
+// localConnectionID is the connection ID chosen by the endpoint during the handshake and peerConnectionID is the one chosen by the peer, both have the sequence number 0.
+// localActiveLimit is the active_connection_id_limit transport parameter sent to the peer.
+// statelessResetKey is the static key used to compute the stateless reset tokens of local connection IDs.
+func NewManager(generator ConnectionIdentifier.ConnectionIDGenerator, localConnectionID, peerConnectionID ConnectionIdentifier.ConnectionID, localActiveLimit varint.Int62, statelessResetKey []byte) *Manager {
+	m := &Manager{
+		generator:                  generator,
+		statelessResetKey:          statelessResetKey,
+		zeroLengthPeerConnectionID: len(peerConnectionID) == 0,
+		nextSequenceNumber:         1,
+		// The active_connection_id_limit of the peer is 2 until its transport parameters are received.
+		peerActiveLimit:  2,
+		localActiveLimit: localActiveLimit,
+		peer:             []PeerConnectionID{{SequenceNumber: 0, ConnectionID: peerConnectionID}},
+		retiredPeer:      map[varint.Int62]bool{},
+	}
+	m.local = []LocalConnectionID{{
+		SequenceNumber:      0,
+		ConnectionID:        localConnectionID,
+		StatelessResetToken: ConnectionIdentifier.NewStatelessResetToken(statelessResetKey, localConnectionID),
+	}}
+	return m
+}
+
+// LocalConnectionIDs returns the active local connection IDs.
+func (m *Manager) LocalConnectionIDs() []LocalConnectionID {
+	return m.local
+}
+
+// IsLocalConnectionID reports whether cid is a active local connection ID.
+func (m *Manager) IsLocalConnectionID(cid ConnectionIdentifier.ConnectionID) bool {
+	for _, local := range m.local {
+		if local.ConnectionID.Equal(cid) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPeerActiveConnectionIDLimit sets the active_connection_id_limit transport parameter received from the peer
+// and issues local connection IDs up to the limit.
+func (m *Manager) SetPeerActiveConnectionIDLimit(limit varint.Int62) error {
+	m.peerActiveLimit = limit
+	return m.issueConnectionIDs()
+}
+
+// SetPeerStatelessResetToken sets the stateless reset token of the connection ID chosen by the server during the handshake.
+// The token is received in the stateless_reset_token transport parameter.
+func (m *Manager) SetPeerStatelessResetToken(token ConnectionIdentifier.StatelessResetToken) {
+	for i := range m.peer {
+		if m.peer[i].SequenceNumber == 0 {
+			m.peer[i].HasStatelessResetToken = true
+			m.peer[i].StatelessResetToken = token
+		}
+	}
+}
+
+// issueConnectionIDs issues local connection IDs until the peer has as many active connection IDs as it accepts.
+// Connection IDs before the localRetirePriorTo that the peer has not retired yet are not counted, the peer cannot use them.
+func (m *Manager) issueConnectionIDs() error {
+	for m.usableLocalConnectionIDs() < min(m.peerActiveLimit, MaxIssuedConnectionIDs) {
+		cid, err := m.generator.GenerateConnectionID()
+		if err != nil {
+			return err
+		}
+		m.local = append(m.local, LocalConnectionID{
+			SequenceNumber:      m.nextSequenceNumber,
+			ConnectionID:        cid,
+			StatelessResetToken: ConnectionIdentifier.NewStatelessResetToken(m.statelessResetKey, cid),
+		})
+		m.pendingNewConnectionID = append(m.pendingNewConnectionID, m.nextSequenceNumber)
+		m.nextSequenceNumber++
+	}
+	return nil
+}
+
+// RetireLocalConnectionIDs requests the peer to retire every local connection ID issued before the new connection IDs.
+// The NEW_CONNECTION_ID frames of the new connection IDs carry the Retire Prior To.
+// The old connection IDs remain active until the peer retires them.
+func (m *Manager) RetireLocalConnectionIDs() error {
+	m.localRetirePriorTo = m.nextSequenceNumber
+	return m.issueConnectionIDs()
+}
+
+// usableLocalConnectionIDs returns the number of local connection IDs the peer may still use.
+func (m *Manager) usableLocalConnectionIDs() varint.Int62 {
+	var n varint.Int62
+	for _, local := range m.local {
+		if local.SequenceNumber >= m.localRetirePriorTo {
+			n++
+		}
+	}
+	return n
+}
+
+// HandleRetireConnectionID retires the local connection ID of the RETIRE_CONNECTION_ID frame and issues a new connection ID to replace it.
+// destinationConnectionID is the Destination Connection ID of the packet carrying the frame.
+//
+// If the sequence number was never issued or refers to the destinationConnectionID HandleRetireConnectionID returns QuicErr.PROTOCOL_VIOLATION.
+func (m *Manager) HandleRetireConnectionID(f *Frame.RetireConnectionIDFrame, destinationConnectionID ConnectionIdentifier.ConnectionID) QuicErr.Err {
+	if f.SequenceNumber >= m.nextSequenceNumber {
+		return QuicErr.PROTOCOL_VIOLATION
+	}
+
+	for i, local := range m.local {
+		if local.SequenceNumber != f.SequenceNumber {
+			continue
+		} else if local.ConnectionID.Equal(destinationConnectionID) {
+			return QuicErr.PROTOCOL_VIOLATION
+		}
+		m.local = append(m.local[:i], m.local[i+1:]...)
+		break
+	}
+
+	// Connection IDs requested to be retired are not replaced one by one.
+	if f.SequenceNumber < m.localRetirePriorTo {
+		return QuicErr.NO_ERROR
+	}
+	if err := m.issueConnectionIDs(); err != nil {
+		return QuicErr.INTERNAL_ERROR
+	}
+	return QuicErr.NO_ERROR
+}
+
+// HandleNewConnectionID adds the connection ID of the NEW_CONNECTION_ID frame and retires the peer connection IDs before the Retire Prior To.
+//
+// If the peer chose a zero-length connection ID, or the sequence number or the connection ID conflicts with a previously issued one
+// HandleNewConnectionID returns QuicErr.PROTOCOL_VIOLATION.
+// If the number of active peer connection IDs exceeds the local active_connection_id_limit, or more than MaxPendingRetireConnectionIDs
+// RETIRE_CONNECTION_ID frames are queued, HandleNewConnectionID returns QuicErr.CONNECTION_ID_LIMIT_ERROR.
+func (m *Manager) HandleNewConnectionID(f *Frame.NewConnectionIDFrame) QuicErr.Err {
+	// An endpoint that is sending packets with a zero-length Destination Connection ID MUST treat receipt of a NEW_CONNECTION_ID frame as a connection error.
+	if m.zeroLengthPeerConnectionID {
+		return QuicErr.PROTOCOL_VIOLATION
+	}
+	if m.retiredPeer[f.SequenceNumber] {
+		// A retransmitted frame of a retired connection ID.
+		return QuicErr.NO_ERROR
+	}
+
+	for _, peer := range m.peer {
+		sameSequenceNumber := peer.SequenceNumber == f.SequenceNumber
+		sameConnectionID := peer.ConnectionID.Equal(f.ConnectionID)
+		if sameSequenceNumber && sameConnectionID && peer.StatelessResetToken == f.StatelessResetToken {
+			// A retransmitted frame.
+			return QuicErr.NO_ERROR
+		} else if sameSequenceNumber || sameConnectionID {
+			return QuicErr.PROTOCOL_VIOLATION
+		}
+	}
+
+	if f.SequenceNumber < m.peerRetirePriorTo {
+		// The connection ID is already retired by a earlier Retire Prior To.
+		m.retirePeerConnectionID(f.SequenceNumber)
+		return m.checkPendingRetireConnectionIDs()
+	}
+
+	m.peer = append(m.peer, PeerConnectionID{
+		SequenceNumber:         f.SequenceNumber,
+		ConnectionID:           f.ConnectionID,
+		HasStatelessResetToken: true,
+		StatelessResetToken:    f.StatelessResetToken,
+	})
+
+	if f.RetirePriorTo > m.peerRetirePriorTo {
+		m.peerRetirePriorTo = f.RetirePriorTo
+		// Sequence numbers below the Retire Prior To are ignored without the record.
+		for sequenceNumber := range m.retiredPeer {
+			if sequenceNumber < m.peerRetirePriorTo {
+				delete(m.retiredPeer, sequenceNumber)
+			}
+		}
+
+		active := m.peer[:0]
+		for _, peer := range m.peer {
+			if peer.SequenceNumber < m.peerRetirePriorTo {
+				m.retirePeerConnectionID(peer.SequenceNumber)
+			} else {
+				active = append(active, peer)
+			}
+		}
+		m.peer = active
+	}
+
+	if varint.Int62(len(m.peer)) > m.localActiveLimit {
+		return QuicErr.CONNECTION_ID_LIMIT_ERROR
+	}
+	return m.checkPendingRetireConnectionIDs()
+}
+
+// checkPendingRetireConnectionIDs limits the RETIRE_CONNECTION_ID frames a peer can queue by raising the Retire Prior To.
+// An endpoint SHOULD limit the number of connection IDs it has retired locally for which RETIRE_CONNECTION_ID frames have not yet been acknowledged.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-5.1.2
+func (m *Manager) checkPendingRetireConnectionIDs() QuicErr.Err {
+	if len(m.pendingRetireConnectionID) > MaxPendingRetireConnectionIDs {
+		return QuicErr.CONNECTION_ID_LIMIT_ERROR
+	}
+	return QuicErr.NO_ERROR
+}
+
+// PeerConnectionID returns the peer connection ID in use as the Destination Connection ID.
+// If every peer connection ID is retired PeerConnectionID returns NoPeerConnectionID.
+func (m *Manager) PeerConnectionID() (ConnectionIdentifier.ConnectionID, error) {
+	if len(m.peer) == 0 {
+		return nil, NoPeerConnectionID
+	}
+	return m.peer[0].ConnectionID, nil
+}
+
+// RotatePeerConnectionID retires the peer connection ID in use and switches to the next one, for example when migrating to a new path.
+// If there is no other peer connection ID RotatePeerConnectionID returns NoPeerConnectionID and the connection ID in use is kept.
+func (m *Manager) RotatePeerConnectionID() error {
+	if len(m.peer) < 2 {
+		return NoPeerConnectionID
+	}
+	m.retirePeerConnectionID(m.peer[0].SequenceNumber)
+	m.peer = m.peer[1:]
+	return nil
+}
+
+// retirePeerConnectionID queues a RETIRE_CONNECTION_ID frame of the peer connection ID.
+func (m *Manager) retirePeerConnectionID(sequenceNumber varint.Int62) {
+	if sequenceNumber >= m.peerRetirePriorTo {
+		m.retiredPeer[sequenceNumber] = true
+	}
+	m.pendingRetireConnectionID = append(m.pendingRetireConnectionID, sequenceNumber)
+}
+
+// IsStatelessReset reports whether token is the stateless reset token of a active peer connection ID.
+func (m *Manager) IsStatelessReset(token ConnectionIdentifier.StatelessResetToken) bool {
+	for _, peer := range m.peer {
+		if peer.HasStatelessResetToken && peer.StatelessResetToken == token {
+			return true
+		}
+	}
+	return false
+}
+
+// NextFrame dequeues the next NEW_CONNECTION_ID or RETIRE_CONNECTION_ID frame that fits in maxLen bytes.
+// If there is no frame to send NextFrame returns false.
+func (m *Manager) NextFrame(maxLen int) (Frame.Frame, bool) {
+	for len(m.pendingRetireConnectionID) != 0 {
+		f := &Frame.RetireConnectionIDFrame{SequenceNumber: m.pendingRetireConnectionID[0]}
+		if f.Len() > maxLen {
+			break
+		}
+		m.pendingRetireConnectionID = m.pendingRetireConnectionID[1:]
+		return f, true
+	}
+
+	for len(m.pendingNewConnectionID) != 0 {
+		local, ok := m.localConnectionID(m.pendingNewConnectionID[0])
+		if !ok {
+			// The connection ID is already retired.
+			m.pendingNewConnectionID = m.pendingNewConnectionID[1:]
+			continue
+		}
+
+		f := &Frame.NewConnectionIDFrame{
+			SequenceNumber:      local.SequenceNumber,
+			RetirePriorTo:       m.localRetirePriorTo,
+			ConnectionID:        local.ConnectionID,
+			StatelessResetToken: local.StatelessResetToken,
+		}
+		if f.Len() > maxLen {
+			break
+		}
+		m.pendingNewConnectionID = m.pendingNewConnectionID[1:]
+		return f, true
+	}
+	return nil, false
+}
+
+// OnFrameLost queues a lost NEW_CONNECTION_ID or RETIRE_CONNECTION_ID frame to be sent again.
+// A NEW_CONNECTION_ID frame of a retired connection ID is not sent again.
+func (m *Manager) OnFrameLost(f Frame.Frame) {
+	switch f := f.(type) {
+	case *Frame.NewConnectionIDFrame:
+		if _, ok := m.localConnectionID(f.SequenceNumber); ok {
+			m.pendingNewConnectionID = append(m.pendingNewConnectionID, f.SequenceNumber)
+		}
+	case *Frame.RetireConnectionIDFrame:
+		m.pendingRetireConnectionID = append(m.pendingRetireConnectionID, f.SequenceNumber)
+	}
+}
+
+func (m *Manager) localConnectionID(sequenceNumber varint.Int62) (LocalConnectionID, bool) {
+	for _, local := range m.local {
+		if local.SequenceNumber == sequenceNumber {
+			return local, true
+		}
+	}
+	return LocalConnectionID{}, false
+}
@@ -0,0 +1,242 @@
+package ConnectionIDManager_test
+
+import (
+	"testing"
+
+	ConnectionIDManager "github.com/udan-jayanith/Quick/connection-id-manager"
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	statelessResetKey = []byte("0123456789abcdef0123456789abcdef")
+)
+
+func newManager() *ConnectionIDManager.Manager {
+	return ConnectionIDManager.NewManager(ConnectionIdentifier.RandomConnectionIDGenerator{Length: 8}, ConnectionIdentifier.ConnectionID{0x01}, ConnectionIdentifier.ConnectionID{0x02}, 3, statelessResetKey)
+}
+
+func newConnectionIDFrame(sequenceNumber, retirePriorTo varint.Int62) *Frame.NewConnectionIDFrame {
+	return &Frame.NewConnectionIDFrame{
+		SequenceNumber:      sequenceNumber,
+		RetirePriorTo:       retirePriorTo,
+		ConnectionID:        ConnectionIdentifier.ConnectionID{0xa0, byte(sequenceNumber)},
+		StatelessResetToken: ConnectionIdentifier.StatelessResetToken{byte(sequenceNumber)},
+	}
+}
+
+// frames dequeues every pending frame.
+func frames(m *ConnectionIDManager.Manager) []Frame.Frame {
+	var fs []Frame.Frame
+	for {
+		f, ok := m.NextFrame(1200)
+		if !ok {
+			return fs
+		}
+		fs = append(fs, f)
+	}
+}
+
+func TestIssueConnectionIDs(t *testing.T) {
+	m := newManager()
+	if err := m.SetPeerActiveConnectionIDLimit(4); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	fs := frames(m)
+	if len(fs) != 3 {
+		t.Fatal("Expected 3 NEW_CONNECTION_ID frames but got", len(fs))
+	}
+	for i, f := range fs {
+		newFrame, ok := f.(*Frame.NewConnectionIDFrame)
+		if !ok {
+			t.Fatal("Expected a NEW_CONNECTION_ID frame but got", f)
+		} else if newFrame.SequenceNumber != varint.Int62(i+1) {
+			t.Fatal("Expected sequence number", i+1, "but got", newFrame.SequenceNumber)
+		} else if newFrame.StatelessResetToken != ConnectionIdentifier.NewStatelessResetToken(statelessResetKey, newFrame.ConnectionID) {
+			t.Fatal("Unexpected stateless reset token of sequence number", newFrame.SequenceNumber)
+		} else if !m.IsLocalConnectionID(newFrame.ConnectionID) {
+			t.Fatal("Expected", newFrame.ConnectionID, "to be a local connection ID")
+		}
+	}
+
+	// A retired connection ID is replaced.
+	if qerr := m.HandleRetireConnectionID(&Frame.RetireConnectionIDFrame{SequenceNumber: 1}, ConnectionIdentifier.ConnectionID{0x01}); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if fs := frames(m); len(fs) != 1 || fs[0].(*Frame.NewConnectionIDFrame).SequenceNumber != 4 {
+		t.Fatal("Expected a NEW_CONNECTION_ID frame of sequence number 4 but got", fs)
+	}
+
+	// The connection ID of the packet carrying the frame can't be retired.
+	if qerr := m.HandleRetireConnectionID(&Frame.RetireConnectionIDFrame{SequenceNumber: 0}, ConnectionIdentifier.ConnectionID{0x01}); qerr != QuicErr.PROTOCOL_VIOLATION {
+		t.Fatal("Expected", QuicErr.PROTOCOL_VIOLATION, "but got", qerr)
+	} else if qerr := m.HandleRetireConnectionID(&Frame.RetireConnectionIDFrame{SequenceNumber: 5}, nil); qerr != QuicErr.PROTOCOL_VIOLATION {
+		t.Fatal("Expected", QuicErr.PROTOCOL_VIOLATION, "but got", qerr)
+	}
+}
+
+func TestRetireLocalConnectionIDs(t *testing.T) {
+	m := newManager()
+	if err := m.RetireLocalConnectionIDs(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	fs := frames(m)
+	if len(fs) != 2 {
+		t.Fatal("Expected 2 NEW_CONNECTION_ID frames but got", len(fs))
+	}
+	for _, f := range fs {
+		if f.(*Frame.NewConnectionIDFrame).RetirePriorTo != 1 {
+			t.Fatal("Expected Retire Prior To 1 but got", f.(*Frame.NewConnectionIDFrame).RetirePriorTo)
+		}
+	}
+
+	// Connection IDs retired by the Retire Prior To are not replaced.
+	if qerr := m.HandleRetireConnectionID(&Frame.RetireConnectionIDFrame{SequenceNumber: 0}, nil); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if fs := frames(m); len(fs) != 0 {
+		t.Fatal("Expected no frames but got", fs)
+	} else if m.IsLocalConnectionID(ConnectionIdentifier.ConnectionID{0x01}) {
+		t.Fatal("Expected the connection ID of sequence number 0 to be retired")
+	}
+}
+
+func TestReplaceConnectionIDBeforeRetirePriorTo(t *testing.T) {
+	m := newManager()
+	if err := m.RetireLocalConnectionIDs(); err != nil {
+		t.Fatal(err.Error())
+	}
+	frames(m)
+
+	// The connection ID of sequence number 0 is not retired yet, but the peer cannot use it, so a new connection ID replaces sequence number 1.
+	if qerr := m.HandleRetireConnectionID(&Frame.RetireConnectionIDFrame{SequenceNumber: 1}, nil); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if fs := frames(m); len(fs) != 1 || fs[0].(*Frame.NewConnectionIDFrame).SequenceNumber != 3 {
+		t.Fatal("Expected a NEW_CONNECTION_ID frame of sequence number 3 but got", fs)
+	}
+}
+
+func TestHandleNewConnectionID(t *testing.T) {
+	m := newManager()
+	for sequenceNumber := varint.Int62(1); sequenceNumber <= 2; sequenceNumber++ {
+		if qerr := m.HandleNewConnectionID(newConnectionIDFrame(sequenceNumber, 0)); qerr != QuicErr.NO_ERROR {
+			t.Fatal(qerr.Error())
+		}
+	}
+
+	// A retransmitted frame is ignored, but a different connection ID with the same sequence number is a error.
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(2, 0)); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	}
+	conflicting := newConnectionIDFrame(2, 0)
+	conflicting.ConnectionID = ConnectionIdentifier.ConnectionID{0xff}
+	if qerr := m.HandleNewConnectionID(conflicting); qerr != QuicErr.PROTOCOL_VIOLATION {
+		t.Fatal("Expected", QuicErr.PROTOCOL_VIOLATION, "but got", qerr)
+	}
+
+	if !m.IsStatelessReset(ConnectionIdentifier.StatelessResetToken{2}) {
+		t.Fatal("Expected the stateless reset token of sequence number 2 to be recognized")
+	}
+
+	// Retire Prior To retires the connection IDs of sequence numbers 0 and 1.
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(3, 2)); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if cid, err := m.PeerConnectionID(); err != nil || !cid.Equal(newConnectionIDFrame(2, 0).ConnectionID) {
+		t.Fatal("Expected the connection ID of sequence number 2 but got", cid, err)
+	}
+	fs := frames(m)
+	if len(fs) != 2 || fs[0].(*Frame.RetireConnectionIDFrame).SequenceNumber != 0 || fs[1].(*Frame.RetireConnectionIDFrame).SequenceNumber != 1 {
+		t.Fatal("Expected RETIRE_CONNECTION_ID frames of sequence numbers 0 and 1 but got", fs)
+	}
+
+	// A connection ID below the Retire Prior To is retired immediately.
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(1, 0)); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if fs := frames(m); len(fs) != 1 || fs[0].(*Frame.RetireConnectionIDFrame).SequenceNumber != 1 {
+		t.Fatal("Expected a RETIRE_CONNECTION_ID frame of sequence number 1 but got", fs)
+	}
+
+	if err := m.RotatePeerConnectionID(); err != nil {
+		t.Fatal(err.Error())
+	} else if cid, _ := m.PeerConnectionID(); !cid.Equal(newConnectionIDFrame(3, 0).ConnectionID) {
+		t.Fatal("Expected the connection ID of sequence number 3 but got", cid)
+	} else if err := m.RotatePeerConnectionID(); err != ConnectionIDManager.NoPeerConnectionID {
+		t.Fatal("Expected", ConnectionIDManager.NoPeerConnectionID, "but got", err)
+	}
+
+	// A retransmitted frame of a retired connection ID is ignored.
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(2, 0)); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if err := m.RotatePeerConnectionID(); err != ConnectionIDManager.NoPeerConnectionID {
+		t.Fatal("Expected", ConnectionIDManager.NoPeerConnectionID, "but got", err)
+	} else if fs := frames(m); len(fs) != 1 || fs[0].(*Frame.RetireConnectionIDFrame).SequenceNumber != 2 {
+		t.Fatal("Expected a RETIRE_CONNECTION_ID frame of sequence number 2 but got", fs)
+	}
+}
+
+func TestZeroLengthConnectionID(t *testing.T) {
+	m := ConnectionIDManager.NewManager(ConnectionIdentifier.RandomConnectionIDGenerator{Length: 8}, ConnectionIdentifier.ConnectionID{0x01}, ConnectionIdentifier.ConnectionID{}, 3, statelessResetKey)
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(1, 0)); qerr != QuicErr.PROTOCOL_VIOLATION {
+		t.Fatal("Expected", QuicErr.PROTOCOL_VIOLATION, "but got", qerr)
+	}
+}
+
+func TestConnectionIDLimit(t *testing.T) {
+	m := newManager()
+	for sequenceNumber := varint.Int62(1); sequenceNumber <= 2; sequenceNumber++ {
+		if qerr := m.HandleNewConnectionID(newConnectionIDFrame(sequenceNumber, 0)); qerr != QuicErr.NO_ERROR {
+			t.Fatal(qerr.Error())
+		}
+	}
+
+	// Connection IDs retired by the same frame don't count toward the limit.
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(3, 1)); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if qerr := m.HandleNewConnectionID(newConnectionIDFrame(4, 1)); qerr != QuicErr.CONNECTION_ID_LIMIT_ERROR {
+		t.Fatal("Expected", QuicErr.CONNECTION_ID_LIMIT_ERROR, "but got", qerr)
+	}
+}
+
+func TestPendingRetireConnectionIDLimit(t *testing.T) {
+	m := newManager()
+	// Each frame retires the connection ID of the previous frame.
+	for sequenceNumber := varint.Int62(1); sequenceNumber <= varint.Int62(ConnectionIDManager.MaxPendingRetireConnectionIDs); sequenceNumber++ {
+		if qerr := m.HandleNewConnectionID(newConnectionIDFrame(sequenceNumber, sequenceNumber)); qerr != QuicErr.NO_ERROR {
+			t.Fatal(qerr.Error())
+		}
+	}
+
+	last := varint.Int62(ConnectionIDManager.MaxPendingRetireConnectionIDs) + 1
+	if qerr := m.HandleNewConnectionID(newConnectionIDFrame(last, last)); qerr != QuicErr.CONNECTION_ID_LIMIT_ERROR {
+		t.Fatal("Expected", QuicErr.CONNECTION_ID_LIMIT_ERROR, "but got", qerr)
+	}
+}
+
+func TestOnFrameLost(t *testing.T) {
+	m := newManager()
+	if err := m.SetPeerActiveConnectionIDLimit(2); err != nil {
+		t.Fatal(err.Error())
+	}
+	fs := frames(m)
+	if len(fs) != 1 {
+		t.Fatal("Expected 1 NEW_CONNECTION_ID frame but got", len(fs))
+	}
+
+	m.OnFrameLost(fs[0])
+	m.OnFrameLost(&Frame.RetireConnectionIDFrame{SequenceNumber: 7})
+	if lost := frames(m); len(lost) != 2 {
+		t.Fatal("Expected 2 frames to be sent again but got", len(lost))
+	}
+
+	// A NEW_CONNECTION_ID frame of a retired connection ID is not sent again.
+	if qerr := m.HandleRetireConnectionID(&Frame.RetireConnectionIDFrame{SequenceNumber: 1}, nil); qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	}
+	frames(m)
+	m.OnFrameLost(fs[0])
+	if lost := frames(m); len(lost) != 0 {
+		t.Fatal("Expected no frames but got", lost)
+	}
+}
@@ -0,0 +1,74 @@
+package ConnectionIdentifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+const (
+	// In QUIC version 1, connection IDs cannot exceed 20 bytes.
+	MaxConnectionIDLength int = 20
+	// Length of a stateless reset token in bytes.
+	StatelessResetTokenLength int = 16
+)
+
+var (
+	InvalidConnectionIDLength error = errors.New("Connection ID cannot exceed 20 bytes")
+)
+
+// ConnectionID is a opaque identifier of a connection.
+// Each endpoint selects connection IDs using an implementation-specific (and perhaps deployment-specific) method.
+type ConnectionID []byte
+
+// IsValid reports whether the length of the ConnectionID is valid in QUIC version 1.
+func (cid ConnectionID) IsValid() bool {
+	return len(cid) <= MaxConnectionIDLength
+}
+
+func (cid ConnectionID) Equal(other ConnectionID) bool {
+	return bytes.Equal(cid, other)
+}
+
+// A stateless reset token is specific to a connection ID.
+// An endpoint that receives a stateless reset token can use it to identify a stateless reset for the connection ID it was issued with.
+type StatelessResetToken [StatelessResetTokenLength]byte
+
+// NewStatelessResetToken computes the stateless reset token of the connection ID with a static key.
+// An endpoint that loses state can compute the same token again from the connection ID of a received packet.
+// The key must be secret and at least 32 bytes long.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-10.3.2
+func NewStatelessResetToken(key []byte, cid ConnectionID) StatelessResetToken {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(cid)
+	return StatelessResetToken(mac.Sum(nil))
+}
+
+// ConnectionIDGenerator generates the local connection IDs of a endpoint.
+// Every connection ID generated by a ConnectionIDGenerator has the same length, so short header packets can be parsed with FixedConnectionIDLength.
+// A ConnectionIDGenerator can be shared by connections, so it must be safe for concurrent use.
+type ConnectionIDGenerator interface {
+	GenerateConnectionID() (ConnectionID, error)
+	ConnectionIDLength() int
+}
+
+// RandomConnectionIDGenerator generates random connection IDs of the Length.
+type RandomConnectionIDGenerator struct {
+	Length int
+}
+
+func (g RandomConnectionIDGenerator) GenerateConnectionID() (ConnectionID, error) {
+	if g.Length < 0 || g.Length > MaxConnectionIDLength {
+		return nil, InvalidConnectionIDLength
+	}
+	cid := make(ConnectionID, g.Length)
+	_, err := rand.Read(cid)
+	return cid, err
+}
+
+func (g RandomConnectionIDGenerator) ConnectionIDLength() int {
+	return g.Length
+}
@@ -0,0 +1,52 @@
+package ConnectionIdentifier_test
+
+import (
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+)
+
+func TestConnectionID_IsValid(t *testing.T) {
+	for _, testcase := range [...]struct {
+		length int
+		valid  bool
+	}{
+		{length: 0, valid: true},
+		{length: 8, valid: true},
+		{length: ConnectionIdentifier.MaxConnectionIDLength, valid: true},
+		{length: ConnectionIdentifier.MaxConnectionIDLength + 1, valid: false},
+	} {
+		cid := make(ConnectionIdentifier.ConnectionID, testcase.length)
+		if cid.IsValid() != testcase.valid {
+			t.Fatal("Expected", testcase.valid, "for a", testcase.length, "bytes long connection ID")
+		}
+	}
+}
+
+func TestNewStatelessResetToken(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	cid := ConnectionIdentifier.ConnectionID{0x01, 0x02, 0x03, 0x04}
+
+	token := ConnectionIdentifier.NewStatelessResetToken(key, cid)
+	if token != ConnectionIdentifier.NewStatelessResetToken(key, cid) {
+		t.Fatal("Expected the same token for the same connection ID")
+	} else if token == ConnectionIdentifier.NewStatelessResetToken(key, ConnectionIdentifier.ConnectionID{0x01}) {
+		t.Fatal("Expected different tokens for different connection IDs")
+	}
+}
+
+func TestRandomConnectionIDGenerator(t *testing.T) {
+	var generator ConnectionIdentifier.ConnectionIDGenerator = ConnectionIdentifier.RandomConnectionIDGenerator{Length: 8}
+	cid, err := generator.GenerateConnectionID()
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(cid) != generator.ConnectionIDLength() {
+		t.Fatal("Expected", generator.ConnectionIDLength(), "bytes long connection ID but got", len(cid))
+	}
+
+	for _, length := range [...]int{-1, 21} {
+		if _, err := (ConnectionIdentifier.RandomConnectionIDGenerator{Length: length}).GenerateConnectionID(); err != ConnectionIdentifier.InvalidConnectionIDLength {
+			t.Fatal("Expected", ConnectionIdentifier.InvalidConnectionIDLength, "but got", err)
+		}
+	}
+}
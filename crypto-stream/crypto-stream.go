@@ -0,0 +1,133 @@
+package CryptoStream
+
+import (
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+// Implementations MUST support buffering at least 4096 bytes of data received in out-of-order CRYPTO frames.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-7.5-2
+const (
+	DefaultMaxBufferSize int = 4096
+)
+
+// byteRange is a interval of received bytes. start is inclusive and end is exclusive.
+type byteRange struct {
+	start, end varint.Int62
+}
+
+// CryptoStream reassembles out of order and overlapping CRYPTO frames of a single encryption level into contiguous handshake bytes.
+type CryptoStream struct {
+	maxBufferSize int
+	// offset is the stream offset of buf[0]. Every byte before offset is already read.
+	offset varint.Int62
+	buf    []byte
+	// received is in ascending order and never contains overlapping or adjacent ranges.
+	received []byteRange
+}
+
+// NewCryptoStream returns a CryptoStream that buffers up to maxBufferSize bytes beyond the read offset.
+func NewCryptoStream(maxBufferSize int) *CryptoStream {
+	return &CryptoStream{
+		maxBufferSize: maxBufferSize,
+	}
+}
+
+// Offset returns the number of bytes read from the stream.
+func (cs *CryptoStream) Offset() varint.Int62 {
+	return cs.offset
+}
+
+// Push buffers the CryptoData of f.
+// Data that was already read is discarded.
+// If the buffered data would exceed the maxBufferSize Push returns QuicErr.CRYPTO_BUFFER_EXCEEDED and nothing is buffered.
+func (cs *CryptoStream) Push(f *Frame.CryptoFrame) QuicErr.Err {
+	start := f.Offset
+	end := f.Offset + varint.Int62(len(f.CryptoData))
+	if end.IsOverflowing() {
+		return QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	if end <= cs.offset || len(f.CryptoData) == 0 {
+		return QuicErr.NO_ERROR
+	}
+	data := f.CryptoData
+	if start < cs.offset {
+		data = data[cs.offset-start:]
+		start = cs.offset
+	}
+
+	if end-cs.offset > varint.Int62(cs.maxBufferSize) {
+		return QuicErr.CRYPTO_BUFFER_EXCEEDED
+	}
+
+	if need := int(end - cs.offset); need > len(cs.buf) {
+		cs.buf = append(cs.buf, make([]byte, need-len(cs.buf))...)
+	}
+	copy(cs.buf[start-cs.offset:], data)
+	cs.addRange(byteRange{start: start, end: end})
+	return QuicErr.NO_ERROR
+}
+
+func (cs *CryptoStream) addRange(r byteRange) {
+	merged := make([]byteRange, 0, len(cs.received)+1)
+	inserted := false
+	for _, current := range cs.received {
+		if current.end < r.start {
+			merged = append(merged, current)
+			continue
+		} else if current.start > r.end {
+			if !inserted {
+				merged = append(merged, r)
+				inserted = true
+			}
+			merged = append(merged, current)
+			continue
+		}
+
+		// current overlaps or is adjacent to r.
+		r.start = min(r.start, current.start)
+		r.end = max(r.end, current.end)
+	}
+	if !inserted {
+		merged = append(merged, r)
+	}
+	cs.received = merged
+}
+
+// Read returns the contiguous bytes available at the read offset and advances the read offset.
+// If no bytes are available Read returns nil.
+func (cs *CryptoStream) Read() []byte {
+	if len(cs.received) == 0 || cs.received[0].start != cs.offset {
+		return nil
+	}
+
+	n := cs.received[0].end - cs.offset
+	data := make([]byte, n)
+	copy(data, cs.buf[:n])
+
+	cs.buf = append(cs.buf[:0], cs.buf[n:]...)
+	cs.offset += n
+	cs.received = cs.received[1:]
+	return data
+}
+
+// CryptoStreams holds a CryptoStream for each packet number space.
+// 0-RTT packets can't carry CRYPTO frames, so the CryptoStream of the Packet.ApplicationDataSpace carries 1-RTT data only.
+type CryptoStreams [3]*CryptoStream
+
+func NewCryptoStreams(maxBufferSize int) CryptoStreams {
+	return CryptoStreams{
+		Packet.InitialSpace:         NewCryptoStream(maxBufferSize),
+		Packet.HandshakeSpace:       NewCryptoStream(maxBufferSize),
+		Packet.ApplicationDataSpace: NewCryptoStream(maxBufferSize),
+	}
+}
+
+// Get returns the CryptoStream of the space.
+func (cs CryptoStreams) Get(space Packet.PacketNumberSpace) *CryptoStream {
+	return cs[space]
+}
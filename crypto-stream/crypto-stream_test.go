@@ -0,0 +1,65 @@
+package CryptoStream_test
+
+import (
+	"bytes"
+	"testing"
+
+	CryptoStream "github.com/udan-jayanith/Quick/crypto-stream"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+func TestCryptoStreamReassembly(t *testing.T) {
+	data := []byte("The quick brown fox jumps over the lazy dog")
+	cs := CryptoStream.NewCryptoStreams(CryptoStream.DefaultMaxBufferSize).Get(Packet.HandshakeSpace)
+
+	// Out of order and overlapping frames.
+	for i, f := range []Frame.CryptoFrame{
+		{Offset: 20, CryptoData: data[20:30]},
+		{Offset: 4, CryptoData: data[4:10]},
+		{Offset: 25, CryptoData: data[25:]},
+		{Offset: 8, CryptoData: data[8:21]},
+	} {
+		if qerr := cs.Push(&f); qerr != QuicErr.NO_ERROR {
+			t.Fatal("Unexpected error", qerr.Error(), "at", i)
+		} else if b := cs.Read(); b != nil {
+			t.Fatalf("Expected no data but got '%s' at %v", b, i)
+		}
+	}
+
+	if qerr := cs.Push(&Frame.CryptoFrame{Offset: 0, CryptoData: data[:5]}); qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	}
+	if b := cs.Read(); !bytes.Equal(b, data) {
+		t.Fatalf("Expected '%s' but got '%s'", data, b)
+	} else if cs.Offset() != varint.Int62(len(data)) {
+		t.Fatal("Expected offset", len(data), "but got", cs.Offset())
+	}
+
+	// Retransmitted data is discarded.
+	if qerr := cs.Push(&Frame.CryptoFrame{Offset: 0, CryptoData: data}); qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if b := cs.Read(); b != nil {
+		t.Fatalf("Expected no data but got '%s'", b)
+	}
+}
+
+func TestCryptoStreamBufferLimit(t *testing.T) {
+	cs := CryptoStream.NewCryptoStream(10)
+	if qerr := cs.Push(&Frame.CryptoFrame{Offset: 5, CryptoData: make([]byte, 6)}); qerr != QuicErr.CRYPTO_BUFFER_EXCEEDED {
+		t.Fatal("Expected", QuicErr.CRYPTO_BUFFER_EXCEEDED.Error(), "but got", qerr.Error())
+	}
+
+	if qerr := cs.Push(&Frame.CryptoFrame{Offset: 0, CryptoData: make([]byte, 10)}); qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if b := cs.Read(); len(b) != 10 {
+		t.Fatal("Expected 10 bytes but got", len(b))
+	}
+
+	// The limit is relative to the read offset.
+	if qerr := cs.Push(&Frame.CryptoFrame{Offset: 15, CryptoData: make([]byte, 5)}); qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	}
+}
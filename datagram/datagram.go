@@ -0,0 +1,156 @@
+package Datagram
+
+import (
+	"errors"
+	"sync"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+const (
+	// Transport parameter ID of the max_datagram_frame_size transport parameter.
+	//
+	// https://datatracker.ietf.org/doc/html/rfc9221#section-3
+	MaxDatagramFrameSizeParameterID varint.Int62 = 0x20
+	// A max_datagram_frame_size of 65535 indicates that the endpoint is willing to accept any DATAGRAM frame that fits inside a QUIC packet.
+	DefaultMaxDatagramFrameSize varint.Int62 = 65535
+	// Number of received datagrams buffered before the oldest datagram is dropped.
+	DefaultReceiveQueueSize int = 128
+)
+
+var (
+	DatagramsDisabled error = errors.New("Peer does not support DATAGRAM frames")
+	DatagramTooLarge  error = errors.New("Datagram is larger than the max_datagram_frame_size of the peer")
+)
+
+// Datagrams queues unreliable datagrams of a connection.
+// Datagrams that are sent are never retransmitted and received datagrams may be dropped when the receive queue is full.
+//
+// Datagrams is safe for concurrent use.
+type Datagrams struct {
+	mu sync.Mutex
+	// max_datagram_frame_size advertised by this endpoint. 0 means DATAGRAM frames are not supported.
+	localMaxFrameSize varint.Int62
+	// max_datagram_frame_size advertised by the peer. 0 means DATAGRAM frames are not supported.
+	peerMaxFrameSize varint.Int62
+	// Size of the largest DATAGRAM frame that fits in a packet on the current path. 0 means it is unknown.
+	pathMaxFrameSize int
+	receiveQueueSize int
+
+	sendQueue    [][]byte
+	receiveQueue [][]byte
+}
+
+// NewDatagrams returns Datagrams that accepts DATAGRAM frames up to localMaxFrameSize bytes.
+// localMaxFrameSize must be advertised to the peer in the max_datagram_frame_size transport parameter.
+func NewDatagrams(localMaxFrameSize varint.Int62) *Datagrams {
+	return &Datagrams{
+		localMaxFrameSize: localMaxFrameSize,
+		receiveQueueSize:  DefaultReceiveQueueSize,
+	}
+}
+
+// SetPeerMaxFrameSize sets the max_datagram_frame_size transport parameter received from the peer.
+func (d *Datagrams) SetPeerMaxFrameSize(peerMaxFrameSize varint.Int62) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.peerMaxFrameSize = peerMaxFrameSize
+}
+
+// SetPathMaxFrameSize sets the size of the largest DATAGRAM frame that fits in a packet on the current path.
+// Queued datagrams that are larger are dropped by NextFrame.
+func (d *Datagrams) SetPathMaxFrameSize(pathMaxFrameSize int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pathMaxFrameSize = pathMaxFrameSize
+}
+
+// fits reports whether the DATAGRAM frame f can ever be sent to the peer on the current path.
+func (d *Datagrams) fits(f *Frame.DatagramFrame) bool {
+	if varint.Int62(f.Len()) > d.peerMaxFrameSize {
+		return false
+	}
+	return d.pathMaxFrameSize == 0 || f.Len() <= d.pathMaxFrameSize
+}
+
+// Send queues data to be sent in a DATAGRAM frame.
+// Send returns DatagramsDisabled if the peer doesn't support DATAGRAM frames and DatagramTooLarge if the DATAGRAM frame would exceed the max_datagram_frame_size of the peer
+// or the path max frame size.
+func (d *Datagrams) Send(data []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.peerMaxFrameSize == 0 {
+		return DatagramsDisabled
+	}
+
+	f := Frame.DatagramFrame{HasLength: true, DatagramData: data}
+	if !d.fits(&f) {
+		return DatagramTooLarge
+	}
+
+	d.sendQueue = append(d.sendQueue, append([]byte(nil), data...))
+	return nil
+}
+
+// Receive returns the oldest received datagram.
+// If no datagram is received Receive returns nil and false.
+func (d *Datagrams) Receive() ([]byte, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.receiveQueue) == 0 {
+		return nil, false
+	}
+	data := d.receiveQueue[0]
+	// The backing array outlives the slice, so it must not reference the dequeued datagram.
+	d.receiveQueue[0] = nil
+	d.receiveQueue = d.receiveQueue[1:]
+	return data, true
+}
+
+// NextFrame dequeues the oldest datagram that fits in maxLen bytes as a DatagramFrame.
+// The datagram is removed from the queue and never retransmitted, even if the packet carrying it is lost.
+// Datagrams that can never be sent on the current path are dropped, so they don't stay in the queue for the life of the connection.
+// If no datagram fits NextFrame returns false.
+func (d *Datagrams) NextFrame(maxLen int) (Frame.DatagramFrame, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	queue := d.sendQueue[:0]
+	next, ok := Frame.DatagramFrame{}, false
+	for _, data := range d.sendQueue {
+		f := Frame.DatagramFrame{HasLength: true, DatagramData: data}
+		switch {
+		case !d.fits(&f):
+		case !ok && f.Len() <= maxLen:
+			next, ok = f, true
+		default:
+			queue = append(queue, data)
+		}
+	}
+	clear(d.sendQueue[len(queue):])
+	d.sendQueue = queue
+	return next, ok
+}
+
+// HandleFrame queues the DatagramData of a received DATAGRAM frame.
+// If the endpoint doesn't support DATAGRAM frames or f is larger than the localMaxFrameSize HandleFrame returns QuicErr.PROTOCOL_VIOLATION.
+func (d *Datagrams) HandleFrame(f *Frame.DatagramFrame) QuicErr.Err {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.localMaxFrameSize == 0 || varint.Int62(f.Len()) > d.localMaxFrameSize {
+		return QuicErr.PROTOCOL_VIOLATION
+	}
+
+	// Datagrams are unreliable. The oldest datagram is dropped when the queue is full.
+	if len(d.receiveQueue) >= d.receiveQueueSize {
+		d.receiveQueue[0] = nil
+		d.receiveQueue = d.receiveQueue[1:]
+	}
+	d.receiveQueue = append(d.receiveQueue, f.DatagramData)
+	return QuicErr.NO_ERROR
+}
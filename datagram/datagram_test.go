@@ -0,0 +1,112 @@
+package Datagram_test
+
+import (
+	"bytes"
+	"testing"
+
+	Datagram "github.com/udan-jayanith/Quick/datagram"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+)
+
+func TestDatagramsSendAndReceive(t *testing.T) {
+	sender := Datagram.NewDatagrams(0)
+	receiver := Datagram.NewDatagrams(Datagram.DefaultMaxDatagramFrameSize)
+
+	if err := sender.Send([]byte("cpu=0.42")); err != Datagram.DatagramsDisabled {
+		t.Fatal("Expected", Datagram.DatagramsDisabled, "but got", err)
+	}
+
+	sender.SetPeerMaxFrameSize(16)
+	if err := sender.Send(make([]byte, 16)); err != Datagram.DatagramTooLarge {
+		t.Fatal("Expected", Datagram.DatagramTooLarge, "but got", err)
+	}
+
+	for _, data := range []string{"cpu=0.42", "mem=0.73"} {
+		if err := sender.Send([]byte(data)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// The first datagram doesn't fit in 5 bytes.
+	if _, ok := sender.NextFrame(5); ok {
+		t.Fatal("Expected no frame")
+	}
+
+	for _, expected := range []string{"cpu=0.42", "mem=0.73"} {
+		f, ok := sender.NextFrame(1200)
+		if !ok {
+			t.Fatal("Expected a frame")
+		}
+		if qerr := receiver.HandleFrame(&f); qerr != QuicErr.NO_ERROR {
+			t.Fatal("Unexpected error", qerr.Error())
+		}
+
+		data, ok := receiver.Receive()
+		if !ok || !bytes.Equal(data, []byte(expected)) {
+			t.Fatalf("Expected '%s' but got '%s'", expected, data)
+		}
+	}
+
+	// Sent datagrams are never retransmitted.
+	if _, ok := sender.NextFrame(1200); ok {
+		t.Fatal("Expected no frame")
+	}
+}
+
+func TestDatagramsHandleFrame(t *testing.T) {
+	f := Frame.DatagramFrame{HasLength: true, DatagramData: make([]byte, 10)}
+
+	if qerr := Datagram.NewDatagrams(0).HandleFrame(&f); qerr != QuicErr.PROTOCOL_VIOLATION {
+		t.Fatal("Expected", QuicErr.PROTOCOL_VIOLATION.Error(), "but got", qerr.Error())
+	} else if qerr := Datagram.NewDatagrams(11).HandleFrame(&f); qerr != QuicErr.PROTOCOL_VIOLATION {
+		t.Fatal("Expected", QuicErr.PROTOCOL_VIOLATION.Error(), "but got", qerr.Error())
+	}
+
+	d := Datagram.NewDatagrams(12)
+	for i := range Datagram.DefaultReceiveQueueSize + 1 {
+		f := Frame.DatagramFrame{HasLength: true, DatagramData: []byte{byte(i)}}
+		if qerr := d.HandleFrame(&f); qerr != QuicErr.NO_ERROR {
+			t.Fatal("Unexpected error", qerr.Error())
+		}
+	}
+
+	// The oldest datagram is dropped when the receive queue is full.
+	if data, ok := d.Receive(); !ok || data[0] != 1 {
+		t.Fatal("Expected the oldest datagram to be dropped")
+	}
+}
+
+func TestDatagramsLargeHeadOfQueue(t *testing.T) {
+	sender := Datagram.NewDatagrams(0)
+	sender.SetPeerMaxFrameSize(Datagram.DefaultMaxDatagramFrameSize)
+
+	for _, data := range [][]byte{make([]byte, 1000), []byte("cpu=0.42")} {
+		if err := sender.Send(data); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// Datagrams behind a datagram that doesn't fit are sent.
+	if f, ok := sender.NextFrame(100); !ok || string(f.DatagramData) != "cpu=0.42" {
+		t.Fatal("Expected 'cpu=0.42' but got", f.DatagramData)
+	}
+
+	// A datagram that can never fit the path is dropped.
+	sender.SetPathMaxFrameSize(500)
+	if _, ok := sender.NextFrame(500); ok {
+		t.Fatal("Expected no frame")
+	}
+	sender.SetPathMaxFrameSize(0)
+	if _, ok := sender.NextFrame(1200); ok {
+		t.Fatal("Expected the large datagram to be dropped")
+	}
+
+	if err := sender.Send(make([]byte, 1000)); err != nil {
+		t.Fatal(err.Error())
+	}
+	sender.SetPathMaxFrameSize(500)
+	if err := sender.Send(make([]byte, 1000)); err != Datagram.DatagramTooLarge {
+		t.Fatal("Expected", Datagram.DatagramTooLarge, "but got", err)
+	}
+}
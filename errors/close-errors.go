@@ -0,0 +1,47 @@
+package QuicErr
+
+import (
+	"strconv"
+
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+// TransportError is the error of a connection closed with a CONNECTION_CLOSE frame of type 0x1c.
+type TransportError struct {
+	Code Err
+	// FrameValue is the frame type value of the frame that triggered the error.
+	// FrameValue is 0 when the frame type is unknown.
+	FrameValue varint.Int62
+	Reason     string
+}
+
+func (te *TransportError) Error() string {
+	msg := te.Code.Error()
+	if msg == "" {
+		// The code is not a error code of QUIC version 1.
+		msg = "Transport error 0x" + strconv.FormatUint(uint64(te.Code), 16)
+	}
+	if te.Reason == "" {
+		return msg
+	}
+	return msg + ": " + te.Reason
+}
+
+func (te *TransportError) Unwrap() error {
+	return te.Code
+}
+
+// ApplicationError is the error of a connection closed with a CONNECTION_CLOSE frame of type 0x1d.
+// The application protocol using QUIC defines the semantics of the Code.
+type ApplicationError struct {
+	Code   varint.Int62
+	Reason string
+}
+
+func (ae *ApplicationError) Error() string {
+	msg := "Application error " + strconv.FormatUint(uint64(ae.Code), 10)
+	if ae.Reason == "" {
+		return msg
+	}
+	return msg + ": " + ae.Reason
+}
@@ -56,3 +56,14 @@ func (code Err) Error() (string) {
 	}
 	return errMap[code]
 }
+
+// CryptoError returns the CRYPTO_ERROR of the TLS alert.
+// The cryptographic handshake uses the range 0x0100-0x01ff, the last significant byte is the TLS alert description.
+func CryptoError(alert uint8) Err {
+	return 0x0100 + Err(alert)
+}
+
+// IsCryptoError reports whether the code is a CRYPTO_ERROR.
+func (code Err) IsCryptoError() bool {
+	return 0x0100 <= code && 0x01ff >= code
+}
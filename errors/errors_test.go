@@ -13,3 +13,44 @@ func TestQuickTransportError(t *testing.T) {
 		t.Fatal("Unexpected error code for quick.NO_ERROR. Expected error code 0x00")
 	}
 }
+
+func TestCryptoError(t *testing.T) {
+	// handshake_failure alert.
+	code := quick.CryptoError(40)
+	if code != 0x0128 || !code.IsCryptoError() {
+		t.Fatal("Unexpected error code for TLS alert 40. Expected error code 0x0128")
+	} else if quick.PROTOCOL_VIOLATION.IsCryptoError() {
+		t.Fatal("quick.PROTOCOL_VIOLATION is not a crypto error")
+	}
+}
+
+func TestApplicationError(t *testing.T) {
+	testcases := [...]struct {
+		Err      quick.ApplicationError
+		Expected string
+	}{
+		{quick.ApplicationError{Code: 0x0102}, "Application error 258"},
+		{quick.ApplicationError{Code: 7, Reason: "shutting down"}, "Application error 7: shutting down"},
+	}
+	for _, testcase := range testcases {
+		if msg := testcase.Err.Error(); msg != testcase.Expected {
+			t.Fatal("Expected", testcase.Expected, "but got", msg)
+		}
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	testcases := [...]struct {
+		Err      quick.TransportError
+		Expected string
+	}{
+		{quick.TransportError{Code: quick.NO_ERROR}, "No error"},
+		{quick.TransportError{Code: 0x4a, Reason: "unknown"}, "Transport error 0x4a: unknown"},
+		{quick.TransportError{Code: 0x4a}, "Transport error 0x4a"},
+	}
+	for _, testcase := range testcases {
+		if msg := testcase.Err.Error(); msg != testcase.Expected {
+			t.Fatal("Expected", testcase.Expected, "but got", msg)
+		}
+	}
+}
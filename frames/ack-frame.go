@@ -0,0 +1,225 @@
+package Frame
+
+import (
+	"bufio"
+	"errors"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	InvalidAckRanges error = errors.New("Ack ranges must be in descending order, non overlapping and separated by at least one unacknowledged packet")
+)
+
+/*
+ACK Frame {
+  Type (i) = 0x02..0x03,
+  Largest Acknowledged (i),
+  ACK Delay (i),
+  ACK Range Count (i),
+  First ACK Range (i),
+  ACK Range (..) ...,
+  [ECN Counts (..)],
+}
+*/
+
+// Receivers send ACK frames (types 0x02 and 0x03) to inform senders of packets they have received and processed.
+type AckFrame struct {
+	// Largest packet number the peer is acknowledging.
+	LargestAcknowledged Packet.PacketNumber
+	// ACK Delay is encoded in microseconds and scaled by the ack_delay_exponent transport parameter.
+	AckDelay varint.Int62
+	// Number of contiguous packets preceding the Largest Acknowledged that are being acknowledged.
+	FirstAckRange varint.Int62
+	// AckRanges are in descending packet number order.
+	AckRanges []AckRange
+	// ECNCounts is nil for ACK frames of type 0x02. Type 0x03 frames carries ECNCounts.
+	ECNCounts *ECNCounts
+}
+
+/*
+ACK Range {
+  Gap (i),
+  ACK Range Length (i),
+}
+*/
+
+type AckRange struct {
+	// Number of contiguous unacknowledged packets preceding the packet number one lower than the smallest in the preceding ACK Range.
+	Gap varint.Int62
+	// Number of contiguous acknowledged packets preceding the largest packet number, as determined by the preceding Gap.
+	Length varint.Int62
+}
+
+/*
+ECN Counts {
+  ECT0 Count (i),
+  ECT1 Count (i),
+  ECN-CE Count (i),
+}
+*/
+
+type ECNCounts struct {
+	ECT0  varint.Int62
+	ECT1  varint.Int62
+	ECNCE varint.Int62
+}
+
+// PacketNumberRange is a interval of acknowledged packet numbers. Both Smallest and Largest are inclusive.
+type PacketNumberRange struct {
+	Smallest, Largest Packet.PacketNumber
+}
+
+// NewAckFrame returns a AckFrame acknowledging the ranges.
+// ranges must be in descending order and two ranges must be separated by at least one unacknowledged packet.
+// If ranges are empty or invalid NewAckFrame returns InvalidAckRanges.
+func NewAckFrame(ranges []PacketNumberRange, ackDelay varint.Int62) (AckFrame, error) {
+	f := AckFrame{
+		AckDelay: ackDelay,
+	}
+	if len(ranges) == 0 {
+		return f, InvalidAckRanges
+	}
+
+	for i, r := range ranges {
+		if r.Smallest > r.Largest || r.Largest.IsOverflowing() {
+			return f, InvalidAckRanges
+		}
+
+		if i == 0 {
+			f.LargestAcknowledged = r.Largest
+			f.FirstAckRange = r.Largest - r.Smallest
+			continue
+		}
+
+		previous := ranges[i-1]
+		// Gap is encoded as one less than the number of unacknowledged packets.
+		if r.Largest+2 > previous.Smallest {
+			return f, InvalidAckRanges
+		}
+		f.AckRanges = append(f.AckRanges, AckRange{
+			Gap:    previous.Smallest - r.Largest - 2,
+			Length: r.Largest - r.Smallest,
+		})
+	}
+	return f, nil
+}
+
+// Ranges returns the acknowledged packet number intervals in descending order.
+// If any range underflows below zero Ranges returns QuicErr.FRAME_ENCODING_ERROR.
+func (f *AckFrame) Ranges() ([]PacketNumberRange, QuicErr.Err) {
+	ranges := make([]PacketNumberRange, 0, len(f.AckRanges)+1)
+	if f.FirstAckRange > f.LargestAcknowledged {
+		return ranges, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	smallest := f.LargestAcknowledged - f.FirstAckRange
+	ranges = append(ranges, PacketNumberRange{
+		Smallest: smallest,
+		Largest:  f.LargestAcknowledged,
+	})
+
+	for _, ackRange := range f.AckRanges {
+		if ackRange.Gap+2 > smallest {
+			return ranges, QuicErr.FRAME_ENCODING_ERROR
+		}
+		largest := smallest - ackRange.Gap - 2
+
+		if ackRange.Length > largest {
+			return ranges, QuicErr.FRAME_ENCODING_ERROR
+		}
+		smallest = largest - ackRange.Length
+
+		ranges = append(ranges, PacketNumberRange{
+			Smallest: smallest,
+			Largest:  largest,
+		})
+	}
+	return ranges, QuicErr.NO_ERROR
+}
+
+// Acknowledges reports whether packetNumber is acknowledged by the AckFrame.
+func (f *AckFrame) Acknowledges(packetNumber Packet.PacketNumber) bool {
+	ranges, _ := f.Ranges()
+	for _, r := range ranges {
+		if packetNumber >= r.Smallest && packetNumber <= r.Largest {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *AckFrame) FrameValue() varint.Int62 {
+	if f.ECNCounts != nil {
+		return 0x03
+	}
+	return 0x02
+}
+
+func (f *AckFrame) Len() int {
+	n := varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.LargestAcknowledged) + varint.VarintLength(f.AckDelay) +
+		varint.VarintLength(varint.Int62(len(f.AckRanges))) + varint.VarintLength(f.FirstAckRange)
+	for _, ackRange := range f.AckRanges {
+		n += varint.VarintLength(ackRange.Gap) + varint.VarintLength(ackRange.Length)
+	}
+	if f.ECNCounts != nil {
+		n += varint.VarintLength(f.ECNCounts.ECT0) + varint.VarintLength(f.ECNCounts.ECT1) + varint.VarintLength(f.ECNCounts.ECNCE)
+	}
+	return n
+}
+
+func (f *AckFrame) Append(b []byte) ([]byte, error) {
+	values := make([]varint.Int62, 0, 5+len(f.AckRanges)*2+3)
+	values = append(values, f.FrameValue(), f.LargestAcknowledged, f.AckDelay, varint.Int62(len(f.AckRanges)), f.FirstAckRange)
+	for _, ackRange := range f.AckRanges {
+		values = append(values, ackRange.Gap, ackRange.Length)
+	}
+	if f.ECNCounts != nil {
+		values = append(values, f.ECNCounts.ECT0, f.ECNCounts.ECT1, f.ECNCounts.ECNCE)
+	}
+	return appendVarints(b, values...)
+}
+
+func ReadAckFrame(rd *bufio.Reader) (AckFrame, QuicErr.Err) {
+	f := AckFrame{}
+
+	frameValue, qerr := readFrameValue(rd, 0x02, 0x03)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	var ackRangeCount varint.Int62
+	for _, v := range [...]*varint.Int62{&f.LargestAcknowledged, &f.AckDelay, &ackRangeCount, &f.FirstAckRange} {
+		if *v, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+			return f, qerr
+		}
+	}
+
+	// ackRangeCount is not trusted for allocation. Each range is at least 2 bytes long.
+	for range ackRangeCount {
+		ackRange := AckRange{}
+		if ackRange.Gap, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+			return f, qerr
+		}
+		if ackRange.Length, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+			return f, qerr
+		}
+		f.AckRanges = append(f.AckRanges, ackRange)
+	}
+
+	if frameValue == 0x03 {
+		f.ECNCounts = &ECNCounts{}
+		for _, v := range [...]*varint.Int62{&f.ECNCounts.ECT0, &f.ECNCounts.ECT1, &f.ECNCounts.ECNCE} {
+			if *v, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+				return f, qerr
+			}
+		}
+	}
+
+	if _, qerr := f.Ranges(); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	return f, QuicErr.NO_ERROR
+}
@@ -0,0 +1,127 @@
+package Frame_test
+
+import (
+	"bufio"
+	"bytes"
+	"slices"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+)
+
+var (
+	ackRangesTestcases = [...][]Frame.PacketNumberRange{
+		{
+			{Smallest: 0, Largest: 0},
+		},
+		{
+			{Smallest: 90, Largest: 100},
+			{Smallest: 50, Largest: 88},
+			{Smallest: 0, Largest: 10},
+		},
+		{
+			{Smallest: 1 << 40, Largest: 1<<40 + 5},
+			{Smallest: 3, Largest: 3},
+			{Smallest: 1, Largest: 1},
+		},
+	}
+)
+
+func TestAckFrameEncodeAndDecode(t *testing.T) {
+	for i, ranges := range ackRangesTestcases {
+		f, err := Frame.NewAckFrame(ranges, 25)
+		if err != nil {
+			t.Fatalf("Test %v failed.\n%s\n%s", i, err.Error(), Testing.ToFormattedJson(ranges))
+		}
+		if i%2 == 0 {
+			f.ECNCounts = &Frame.ECNCounts{ECT0: 1, ECT1: 2, ECNCE: 3}
+		}
+
+		b, err := Frame.Encode(&f)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(b) != f.Len() {
+			t.Fatal("Expected", f.Len(), "bytes but got", len(b))
+		}
+
+		newFrame, qerr := Frame.ReadAckFrame(bufio.NewReader(bytes.NewReader(b)))
+		if qerr != QuicErr.NO_ERROR {
+			t.Fatalf("Test %v failed.\nUnexpected error '%s'", i, qerr.Error())
+		} else if newFrame.AckDelay != 25 || (newFrame.ECNCounts == nil) != (f.ECNCounts == nil) {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(f), Testing.ToFormattedJson(newFrame))
+		} else if f.ECNCounts != nil && *f.ECNCounts != *newFrame.ECNCounts {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(f), Testing.ToFormattedJson(newFrame))
+		}
+
+		newRanges, qerr := newFrame.Ranges()
+		if qerr != QuicErr.NO_ERROR {
+			t.Fatalf("Test %v failed.\nUnexpected error '%s'", i, qerr.Error())
+		} else if !slices.Equal(ranges, newRanges) {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(ranges), Testing.ToFormattedJson(newRanges))
+		}
+
+		for _, r := range ranges {
+			if !newFrame.Acknowledges(r.Smallest) || !newFrame.Acknowledges(r.Largest) {
+				t.Fatalf("Test %v failed.\nExpected %v to be acknowledged", i, r)
+			}
+		}
+	}
+}
+
+func TestNewAckFrameInvalidRanges(t *testing.T) {
+	for i, ranges := range [...][]Frame.PacketNumberRange{
+		{},
+		{{Smallest: 5, Largest: 4}},
+		// Ranges must be separated by at least one unacknowledged packet.
+		{{Smallest: 5, Largest: 10}, {Smallest: 0, Largest: 4}},
+		// Ranges must be in descending order.
+		{{Smallest: 0, Largest: 4}, {Smallest: 6, Largest: 10}},
+	} {
+		if _, err := Frame.NewAckFrame(ranges, 0); err != Frame.InvalidAckRanges {
+			t.Fatalf("Test %v failed.\nExpected error '%v' but got '%v'", i, Frame.InvalidAckRanges, err)
+		}
+	}
+}
+
+var (
+	readAckFrameErrorTestcases = [...]struct {
+		Input []byte
+		Err   QuicErr.Err
+	}{
+		{
+			// First ACK Range larger than Largest Acknowledged.
+			Input: []byte{0x02, 0x05, 0x00, 0x00, 0x06},
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// Gap underflows below zero.
+			Input: []byte{0x02, 0x05, 0x00, 0x01, 0x02, 0x02, 0x00},
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// ACK Range Length underflows below zero.
+			Input: []byte{0x02, 0x0a, 0x00, 0x01, 0x00, 0x00, 0x09},
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// Missing ECN Counts.
+			Input: []byte{0x03, 0x0a, 0x00, 0x00, 0x00, 0x01},
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			Input: []byte{0x02, 0x0a, 0x00, 0x01, 0x00, 0x00, 0x08},
+			Err:   QuicErr.NO_ERROR,
+		},
+	}
+)
+
+func TestReadAckFrameErrors(t *testing.T) {
+	for i, testcase := range readAckFrameErrorTestcases {
+		_, qerr := Frame.ReadAckFrame(bufio.NewReader(bytes.NewReader(testcase.Input)))
+		if qerr != testcase.Err {
+			t.Fatalf("Test %v failed.\nExpected error '%s' but got '%s'\n%s", i, testcase.Err.Error(), qerr.Error(), Testing.ToFormattedJson(testcase))
+		}
+	}
+}
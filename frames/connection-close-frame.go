@@ -0,0 +1,158 @@
+package Frame
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+/*
+CONNECTION_CLOSE Frame {
+  Type (i) = 0x1c..0x1d,
+  Error Code (i),
+  [Frame Type (i)],
+  Reason Phrase Length (i),
+  Reason Phrase (..),
+}
+*/
+
+// An endpoint sends a CONNECTION_CLOSE frame to notify its peer that the connection is being closed.
+// The CONNECTION_CLOSE frame with a type of 0x1c is used to signal errors at only the QUIC layer, or the absence of errors (with the NO_ERROR code).
+// The CONNECTION_CLOSE frame with a type of 0x1d is used to signal an error with the application that uses QUIC.
+type ConnectionCloseFrame struct {
+	// IsApplicationClose is true for CONNECTION_CLOSE frames of type 0x1d.
+	IsApplicationClose bool
+	// TransportError is used only when IsApplicationClose is false.
+	TransportError QuicErr.Err
+	// The type of frame that triggered the error. A value of 0 (equivalent to the mention of the PADDING frame) is used when the frame type is unknown.
+	// FrameType is used only when IsApplicationClose is false.
+	FrameType varint.Int62
+	// ApplicationErrorCode is used only when IsApplicationClose is true.
+	ApplicationErrorCode varint.Int62
+	// Additional diagnostic information for the closure. This SHOULD be a UTF-8 encoded string.
+	ReasonPhrase string
+}
+
+// NewConnectionCloseFrame returns the ConnectionCloseFrame that closes the connection with err.
+//
+// A *QuicErr.ApplicationError closes the connection with a CONNECTION_CLOSE frame of type 0x1d.
+// A *QuicErr.TransportError or a QuicErr.Err closes the connection with a CONNECTION_CLOSE frame of type 0x1c.
+// A nil err closes the connection with QuicErr.NO_ERROR and any other error closes the connection with QuicErr.INTERNAL_ERROR.
+func NewConnectionCloseFrame(err error) ConnectionCloseFrame {
+	var applicationErr *QuicErr.ApplicationError
+	var transportErr *QuicErr.TransportError
+	var qerr QuicErr.Err
+
+	if err == nil {
+		return ConnectionCloseFrame{TransportError: QuicErr.NO_ERROR}
+	} else if errors.As(err, &applicationErr) {
+		return ConnectionCloseFrame{
+			IsApplicationClose:   true,
+			ApplicationErrorCode: applicationErr.Code,
+			ReasonPhrase:         applicationErr.Reason,
+		}
+	} else if errors.As(err, &transportErr) {
+		return ConnectionCloseFrame{
+			TransportError: transportErr.Code,
+			FrameType:      transportErr.FrameValue,
+			ReasonPhrase:   transportErr.Reason,
+		}
+	} else if errors.As(err, &qerr) {
+		return ConnectionCloseFrame{TransportError: qerr}
+	}
+	return ConnectionCloseFrame{TransportError: QuicErr.INTERNAL_ERROR}
+}
+
+// Err returns the error the connection was closed with.
+// Err returns a *QuicErr.ApplicationError or a *QuicErr.TransportError.
+func (f *ConnectionCloseFrame) Err() error {
+	if f.IsApplicationClose {
+		return &QuicErr.ApplicationError{
+			Code:   f.ApplicationErrorCode,
+			Reason: f.ReasonPhrase,
+		}
+	}
+	return &QuicErr.TransportError{
+		Code:       f.TransportError,
+		FrameValue: f.FrameType,
+		Reason:     f.ReasonPhrase,
+	}
+}
+
+// ForHandshake returns the ConnectionCloseFrame that can be sent in Initial or Handshake packets.
+// A CONNECTION_CLOSE of type 0x1d is replaced by a CONNECTION_CLOSE of type 0x1c with APPLICATION_ERROR and a empty reason phrase,
+// as the application state might be revealed before the handshake is confirmed.
+func (f *ConnectionCloseFrame) ForHandshake() ConnectionCloseFrame {
+	if !f.IsApplicationClose {
+		return *f
+	}
+	return ConnectionCloseFrame{TransportError: QuicErr.APPLICATION_ERROR}
+}
+
+func (f *ConnectionCloseFrame) FrameValue() varint.Int62 {
+	if f.IsApplicationClose {
+		return 0x1d
+	}
+	return 0x1c
+}
+
+func (f *ConnectionCloseFrame) Len() int {
+	n := varint.VarintLength(f.FrameValue()) + varint.VarintLength(varint.Int62(len(f.ReasonPhrase))) + len(f.ReasonPhrase)
+	if f.IsApplicationClose {
+		return n + varint.VarintLength(f.ApplicationErrorCode)
+	}
+	return n + varint.VarintLength(varint.Int62(f.TransportError)) + varint.VarintLength(f.FrameType)
+}
+
+func (f *ConnectionCloseFrame) Append(b []byte) ([]byte, error) {
+	var buf []byte
+	var err error
+	if f.IsApplicationClose {
+		buf, err = appendVarints(b, f.FrameValue(), f.ApplicationErrorCode, varint.Int62(len(f.ReasonPhrase)))
+	} else {
+		buf, err = appendVarints(b, f.FrameValue(), varint.Int62(f.TransportError), f.FrameType, varint.Int62(len(f.ReasonPhrase)))
+	}
+	if err != nil {
+		return b, err
+	}
+	return append(buf, f.ReasonPhrase...), nil
+}
+
+func ReadConnectionCloseFrame(rd *bufio.Reader) (ConnectionCloseFrame, QuicErr.Err) {
+	f := ConnectionCloseFrame{}
+	frameValue, qerr := readFrameValue(rd, 0x1c, 0x1d)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.IsApplicationClose = frameValue == 0x1d
+
+	errorCode, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	if f.IsApplicationClose {
+		f.ApplicationErrorCode = errorCode
+	} else {
+		f.TransportError = QuicErr.Err(errorCode)
+		if f.FrameType, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+			return f, qerr
+		}
+	}
+
+	length, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	// length is not trusted for allocation.
+	reasonPhrase, err := io.ReadAll(io.LimitReader(rd, int64(length)))
+	if err != nil || varint.Int62(len(reasonPhrase)) != length {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	f.ReasonPhrase = string(reasonPhrase)
+	return f, QuicErr.NO_ERROR
+}
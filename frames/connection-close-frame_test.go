@@ -0,0 +1,100 @@
+package Frame_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+)
+
+func TestConnectionCloseFrameEncodeAndDecode(t *testing.T) {
+	for i, f := range [...]Frame.ConnectionCloseFrame{
+		{
+			TransportError: QuicErr.FLOW_CONTROL_ERROR,
+			FrameType:      0x08,
+			ReasonPhrase:   "stream 4 exceeded its limit",
+		},
+		{
+			TransportError: QuicErr.NO_ERROR,
+		},
+		{
+			IsApplicationClose:   true,
+			ApplicationErrorCode: 0x0101,
+			ReasonPhrase:         "bye",
+		},
+	} {
+		b, err := Frame.Encode(&f)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(b) != f.Len() {
+			t.Fatal("Expected", f.Len(), "bytes but got", len(b), "at", i)
+		}
+
+		newFrame, qerr := Frame.ReadConnectionCloseFrame(bufio.NewReader(bytes.NewReader(b)))
+		if qerr != QuicErr.NO_ERROR {
+			t.Fatal("Unexpected error", qerr.Error(), "at", i)
+		} else if newFrame != f {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(f), Testing.ToFormattedJson(newFrame))
+		}
+
+		// Reason Phrase shorter than the Reason Phrase Length.
+		if len(f.ReasonPhrase) != 0 {
+			if _, qerr := Frame.ReadConnectionCloseFrame(bufio.NewReader(bytes.NewReader(b[:len(b)-1]))); qerr != QuicErr.FRAME_ENCODING_ERROR {
+				t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error(), "at", i)
+			}
+		}
+	}
+}
+
+func TestConnectionCloseFrameErrorConversion(t *testing.T) {
+	for i, testcase := range []struct {
+		Err   error
+		Frame Frame.ConnectionCloseFrame
+	}{
+		{
+			Err:   nil,
+			Frame: Frame.ConnectionCloseFrame{TransportError: QuicErr.NO_ERROR},
+		},
+		{
+			Err:   QuicErr.PROTOCOL_VIOLATION,
+			Frame: Frame.ConnectionCloseFrame{TransportError: QuicErr.PROTOCOL_VIOLATION},
+		},
+		{
+			Err:   fmt.Errorf("closing: %w", &QuicErr.TransportError{Code: QuicErr.STREAM_STATE_ERROR, FrameValue: 0x04, Reason: "reset"}),
+			Frame: Frame.ConnectionCloseFrame{TransportError: QuicErr.STREAM_STATE_ERROR, FrameType: 0x04, ReasonPhrase: "reset"},
+		},
+		{
+			Err:   &QuicErr.ApplicationError{Code: 42, Reason: "done"},
+			Frame: Frame.ConnectionCloseFrame{IsApplicationClose: true, ApplicationErrorCode: 42, ReasonPhrase: "done"},
+		},
+		{
+			Err:   errors.New("unexpected"),
+			Frame: Frame.ConnectionCloseFrame{TransportError: QuicErr.INTERNAL_ERROR},
+		},
+	} {
+		f := Frame.NewConnectionCloseFrame(testcase.Err)
+		if f != testcase.Frame {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(testcase.Frame), Testing.ToFormattedJson(f))
+		}
+
+		// The frame must convert back to the same frame.
+		if newFrame := Frame.NewConnectionCloseFrame(f.Err()); newFrame != f {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(f), Testing.ToFormattedJson(newFrame))
+		}
+	}
+
+	f := Frame.ConnectionCloseFrame{TransportError: QuicErr.FRAME_ENCODING_ERROR}
+	if !errors.Is(f.Err(), QuicErr.FRAME_ENCODING_ERROR) {
+		t.Fatal("Expected the error to be", QuicErr.FRAME_ENCODING_ERROR.Error())
+	}
+
+	f = Frame.NewConnectionCloseFrame(&QuicErr.ApplicationError{Code: 42, Reason: "secret"})
+	if handshakeFrame := f.ForHandshake(); handshakeFrame.IsApplicationClose || handshakeFrame.TransportError != QuicErr.APPLICATION_ERROR || handshakeFrame.ReasonPhrase != "" {
+		t.Fatalf("Unexpected frame\n%s", Testing.ToFormattedJson(handshakeFrame))
+	}
+}
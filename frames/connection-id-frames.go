@@ -0,0 +1,138 @@
+package Frame
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	InvalidRetirePriorTo error = errors.New("Retire Prior To cannot be greater than the Sequence Number")
+)
+
+/*
+NEW_CONNECTION_ID Frame {
+  Type (i) = 0x18,
+  Sequence Number (i),
+  Retire Prior To (i),
+  Length (8),
+  Connection ID (8..160),
+  Stateless Reset Token (128),
+}
+*/
+
+// An endpoint sends a NEW_CONNECTION_ID frame to provide its peer with alternative connection IDs that can be used to break linkability when migrating connections.
+type NewConnectionIDFrame struct {
+	// The sequence number assigned to the connection ID by the sender.
+	SequenceNumber varint.Int62
+	// Indicates which connection IDs should be retired.
+	// RetirePriorTo cannot be greater than the SequenceNumber.
+	RetirePriorTo varint.Int62
+	// ConnectionID must be 1 to 20 bytes long.
+	ConnectionID        ConnectionIdentifier.ConnectionID
+	StatelessResetToken ConnectionIdentifier.StatelessResetToken
+}
+
+func (f *NewConnectionIDFrame) FrameValue() varint.Int62 {
+	return 0x18
+}
+
+func (f *NewConnectionIDFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.SequenceNumber) + varint.VarintLength(f.RetirePriorTo) +
+		1 + len(f.ConnectionID) + ConnectionIdentifier.StatelessResetTokenLength
+}
+
+// Append appends the encoded NewConnectionIDFrame to b.
+// Append returns b and ConnectionIdentifier.InvalidConnectionIDLength if the ConnectionID is empty or longer than 20 bytes,
+// and InvalidRetirePriorTo if the RetirePriorTo is greater than the SequenceNumber.
+func (f *NewConnectionIDFrame) Append(b []byte) ([]byte, error) {
+	if len(f.ConnectionID) == 0 || !f.ConnectionID.IsValid() {
+		return b, ConnectionIdentifier.InvalidConnectionIDLength
+	} else if f.RetirePriorTo > f.SequenceNumber {
+		return b, InvalidRetirePriorTo
+	}
+
+	buf, err := appendVarints(b, f.FrameValue(), f.SequenceNumber, f.RetirePriorTo)
+	if err != nil {
+		return b, err
+	}
+	buf = append(buf, byte(len(f.ConnectionID)))
+	buf = append(buf, f.ConnectionID...)
+	return append(buf, f.StatelessResetToken[:]...), nil
+}
+
+func ReadNewConnectionIDFrame(rd *bufio.Reader) (NewConnectionIDFrame, QuicErr.Err) {
+	f := NewConnectionIDFrame{}
+	if _, qerr := readFrameValue(rd, 0x18, 0x18); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	var qerr QuicErr.Err
+	if f.SequenceNumber, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	if f.RetirePriorTo, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	// Receiving a value in the Retire Prior To field that is greater than that in the Sequence Number field MUST be treated as a connection error of type FRAME_ENCODING_ERROR.
+	if f.RetirePriorTo > f.SequenceNumber {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	// Values less than 1 and greater than 20 are invalid and MUST be treated as a connection error of type FRAME_ENCODING_ERROR.
+	length, err := rd.ReadByte()
+	if err != nil || length < 1 || int(length) > ConnectionIdentifier.MaxConnectionIDLength {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	f.ConnectionID = make(ConnectionIdentifier.ConnectionID, length)
+	if _, err := io.ReadFull(rd, f.ConnectionID); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	if _, err := io.ReadFull(rd, f.StatelessResetToken[:]); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return f, QuicErr.NO_ERROR
+}
+
+/*
+RETIRE_CONNECTION_ID Frame {
+  Type (i) = 0x19,
+  Sequence Number (i),
+}
+*/
+
+// An endpoint sends a RETIRE_CONNECTION_ID frame to indicate that it will no longer use a connection ID that was issued by its peer.
+type RetireConnectionIDFrame struct {
+	// The sequence number of the connection ID being retired.
+	SequenceNumber varint.Int62
+}
+
+func (f *RetireConnectionIDFrame) FrameValue() varint.Int62 {
+	return 0x19
+}
+
+func (f *RetireConnectionIDFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.SequenceNumber)
+}
+
+func (f *RetireConnectionIDFrame) Append(b []byte) ([]byte, error) {
+	return appendVarints(b, f.FrameValue(), f.SequenceNumber)
+}
+
+func ReadRetireConnectionIDFrame(rd *bufio.Reader) (RetireConnectionIDFrame, QuicErr.Err) {
+	f := RetireConnectionIDFrame{}
+	if _, qerr := readFrameValue(rd, 0x19, 0x19); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	var qerr QuicErr.Err
+	f.SequenceNumber, qerr = readVarint(rd)
+	return f, qerr
+}
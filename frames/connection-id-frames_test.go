@@ -0,0 +1,102 @@
+package Frame_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+)
+
+func TestNewConnectionIDFrameEncodeAndDecode(t *testing.T) {
+	f := Frame.NewConnectionIDFrame{
+		SequenceNumber: 5,
+		RetirePriorTo:  2,
+		ConnectionID:   ConnectionIdentifier.ConnectionID{0x83, 0x94, 0xc8, 0xf0, 0x3e, 0x51, 0x57, 0x08},
+	}
+	copy(f.StatelessResetToken[:], "0123456789abcdef")
+
+	b, err := Frame.Encode(&f)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != f.Len() {
+		t.Fatal("Expected", f.Len(), "bytes but got", len(b))
+	}
+
+	newFrame, qerr := Frame.ReadNewConnectionIDFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newFrame.SequenceNumber != f.SequenceNumber || newFrame.RetirePriorTo != f.RetirePriorTo ||
+		!newFrame.ConnectionID.Equal(f.ConnectionID) || newFrame.StatelessResetToken != f.StatelessResetToken {
+		t.Fatalf("Expected\n%s\nbut got\n%s", Testing.ToFormattedJson(f), Testing.ToFormattedJson(newFrame))
+	}
+}
+
+func TestNewConnectionIDFrameErrors(t *testing.T) {
+	token := make([]byte, ConnectionIdentifier.StatelessResetTokenLength)
+	for i, testcase := range [...]struct {
+		Input []byte
+		Err   QuicErr.Err
+	}{
+		{
+			// Retire Prior To greater than the Sequence Number.
+			Input: append([]byte{0x18, 0x01, 0x02, 0x01, 0xff}, token...),
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// Zero length connection ID.
+			Input: append([]byte{0x18, 0x01, 0x00, 0x00}, token...),
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// 21 bytes long connection ID.
+			Input: append(append([]byte{0x18, 0x01, 0x00, 21}, make([]byte, 21)...), token...),
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// Truncated stateless reset token.
+			Input: append([]byte{0x18, 0x01, 0x00, 0x01, 0xff}, token[1:]...),
+			Err:   QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			Input: append(append([]byte{0x18, 0x01, 0x01, 20}, make([]byte, 20)...), token...),
+			Err:   QuicErr.NO_ERROR,
+		},
+	} {
+		if _, qerr := Frame.ReadNewConnectionIDFrame(bufio.NewReader(bytes.NewReader(testcase.Input))); qerr != testcase.Err {
+			t.Fatalf("Test %v failed.\nExpected error '%s' but got '%s'", i, testcase.Err.Error(), qerr.Error())
+		}
+	}
+
+	for _, f := range []Frame.NewConnectionIDFrame{
+		{SequenceNumber: 1, ConnectionID: ConnectionIdentifier.ConnectionID{}},
+		{SequenceNumber: 1, ConnectionID: make(ConnectionIdentifier.ConnectionID, 21)},
+	} {
+		if _, err := Frame.Encode(&f); err != ConnectionIdentifier.InvalidConnectionIDLength {
+			t.Fatal("Expected", ConnectionIdentifier.InvalidConnectionIDLength, "but got", err)
+		}
+	}
+
+	f := Frame.NewConnectionIDFrame{SequenceNumber: 1, RetirePriorTo: 2, ConnectionID: ConnectionIdentifier.ConnectionID{1}}
+	if _, err := Frame.Encode(&f); err != Frame.InvalidRetirePriorTo {
+		t.Fatal("Expected", Frame.InvalidRetirePriorTo, "but got", err)
+	}
+}
+
+func TestRetireConnectionIDFrameEncodeAndDecode(t *testing.T) {
+	f := Frame.RetireConnectionIDFrame{SequenceNumber: 1 << 20}
+	b, err := Frame.Encode(&f)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	newFrame, qerr := Frame.ReadRetireConnectionIDFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newFrame != f {
+		t.Fatal("Expected", f, "but got", newFrame)
+	}
+}
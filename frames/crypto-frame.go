@@ -0,0 +1,85 @@
+package Frame
+
+import (
+	"bufio"
+	"io"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+/*
+CRYPTO Frame {
+  Type (i) = 0x06,
+  Offset (i),
+  Length (i),
+  Crypto Data (..),
+}
+*/
+
+// A CRYPTO frame is used to transmit cryptographic handshake messages.
+// CRYPTO frames are functionally identical to STREAM frames, except that they do not bear a stream identifier.
+type CryptoFrame struct {
+	// Byte offset in the stream for the data in this CRYPTO frame.
+	Offset varint.Int62
+	// Length of the CryptoData is encoded as a variable length integer.
+	CryptoData []byte
+}
+
+func (f *CryptoFrame) FrameValue() varint.Int62 {
+	return 0x06
+}
+
+func (f *CryptoFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.Offset) +
+		varint.VarintLength(varint.Int62(len(f.CryptoData))) + len(f.CryptoData)
+}
+
+// Append appends the encoded CryptoFrame to b.
+// If the sum of the Offset and the length of the CryptoData is overflowing Append returns b and varint.IntegerOverflow.
+func (f *CryptoFrame) Append(b []byte) ([]byte, error) {
+	length := varint.Int62(len(f.CryptoData))
+	if (f.Offset + length).IsOverflowing() {
+		return b, varint.IntegerOverflow
+	}
+
+	buf, err := appendVarints(b, f.FrameValue(), f.Offset, length)
+	if err != nil {
+		return b, err
+	}
+	return append(buf, f.CryptoData...), nil
+}
+
+func ReadCryptoFrame(rd *bufio.Reader) (CryptoFrame, QuicErr.Err) {
+	f := CryptoFrame{}
+
+	if _, qerr := readFrameValue(rd, 0x06, 0x06); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	var qerr QuicErr.Err
+	if f.Offset, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	length, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	/*
+		The largest offset delivered on a stream -- the sum of the offset and data length -- cannot exceed 2^62-1.
+		Receipt of a frame that exceeds this limit MUST be treated as a connection error of type FRAME_ENCODING_ERROR or CRYPTO_BUFFER_EXCEEDED.
+	*/
+	if (f.Offset + length).IsOverflowing() {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	// length is not trusted for allocation.
+	data, err := io.ReadAll(io.LimitReader(rd, int64(length)))
+	if err != nil || varint.Int62(len(data)) != length {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	f.CryptoData = data
+	return f, QuicErr.NO_ERROR
+}
@@ -0,0 +1,42 @@
+package Frame_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+func TestCryptoFrameEncodeAndDecode(t *testing.T) {
+	f := Frame.CryptoFrame{
+		Offset:     1 << 20,
+		CryptoData: []byte("client hello"),
+	}
+
+	b, err := Frame.Encode(&f)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != f.Len() {
+		t.Fatal("Expected", f.Len(), "bytes but got", len(b))
+	}
+
+	newFrame, qerr := Frame.ReadCryptoFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newFrame.Offset != f.Offset || !bytes.Equal(newFrame.CryptoData, f.CryptoData) {
+		t.Fatalf("Expected %v but got %v", f, newFrame)
+	}
+
+	// Crypto Data shorter than the Length.
+	if _, qerr := Frame.ReadCryptoFrame(bufio.NewReader(bytes.NewReader(b[:len(b)-1]))); qerr != QuicErr.FRAME_ENCODING_ERROR {
+		t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+	}
+
+	f.Offset = varint.MaxInt62
+	if _, err := Frame.Encode(&f); err != varint.IntegerOverflow {
+		t.Fatal("Expected", varint.IntegerOverflow, "but got", err)
+	}
+}
@@ -0,0 +1,87 @@
+package Frame
+
+import (
+	"bufio"
+	"io"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+/*
+DATAGRAM Frame {
+  Type (i) = 0x30..0x31,
+  [Length (i)],
+  Datagram Data (..),
+}
+*/
+
+// DATAGRAM frames are used to transmit application data in an unreliable manner.
+// DATAGRAM frames are never retransmitted.
+//
+// https://datatracker.ietf.org/doc/html/rfc9221#section-4
+type DatagramFrame struct {
+	// HasLength is true for DATAGRAM frames of type 0x31.
+	// A DATAGRAM frame without the Length field extends to the end of the packet.
+	HasLength    bool
+	DatagramData []byte
+}
+
+func (f *DatagramFrame) FrameValue() varint.Int62 {
+	if f.HasLength {
+		return 0x31
+	}
+	return 0x30
+}
+
+func (f *DatagramFrame) Len() int {
+	n := varint.VarintLength(f.FrameValue()) + len(f.DatagramData)
+	if f.HasLength {
+		n += varint.VarintLength(varint.Int62(len(f.DatagramData)))
+	}
+	return n
+}
+
+func (f *DatagramFrame) Append(b []byte) ([]byte, error) {
+	buf := append(b, byte(f.FrameValue()))
+	if f.HasLength {
+		var err error
+		if buf, err = appendVarints(buf, varint.Int62(len(f.DatagramData))); err != nil {
+			return b, err
+		}
+	}
+	return append(buf, f.DatagramData...), nil
+}
+
+// ReadDatagramFrame reads a DATAGRAM frame.
+// A DATAGRAM frame of type 0x30 consumes every byte left in the reader.
+func ReadDatagramFrame(rd *bufio.Reader) (DatagramFrame, QuicErr.Err) {
+	f := DatagramFrame{}
+	frameValue, qerr := readFrameValue(rd, 0x30, 0x31)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.HasLength = frameValue == 0x31
+
+	if !f.HasLength {
+		data, err := io.ReadAll(rd)
+		if err != nil {
+			return f, QuicErr.FRAME_ENCODING_ERROR
+		}
+		f.DatagramData = data
+		return f, QuicErr.NO_ERROR
+	}
+
+	length, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	// length is not trusted for allocation.
+	data, err := io.ReadAll(io.LimitReader(rd, int64(length)))
+	if err != nil || varint.Int62(len(data)) != length {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	f.DatagramData = data
+	return f, QuicErr.NO_ERROR
+}
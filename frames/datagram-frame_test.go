@@ -0,0 +1,47 @@
+package Frame_test
+
+import (
+	"bytes"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+)
+
+func TestDatagramFrameEncodeAndDecode(t *testing.T) {
+	withLength := Frame.DatagramFrame{HasLength: true, DatagramData: []byte("cpu=0.42")}
+	withoutLength := Frame.DatagramFrame{DatagramData: []byte("mem=0.73")}
+
+	payload := make([]byte, 0)
+	for _, f := range []*Frame.DatagramFrame{&withLength, &withoutLength} {
+		b, err := f.Append(payload)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(b)-len(payload) != f.Len() {
+			t.Fatal("Expected", f.Len(), "bytes but got", len(b)-len(payload))
+		}
+		payload = b
+	}
+
+	frames, _, qerr := Frame.ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if len(frames) != 2 {
+		t.Fatal("Expected 2 frames but got", len(frames))
+	}
+
+	for i, expected := range []*Frame.DatagramFrame{&withLength, &withoutLength} {
+		f, ok := frames[i].(*Frame.DatagramFrame)
+		if !ok {
+			t.Fatalf("Expected a *Frame.DatagramFrame but got %T", frames[i])
+		} else if f.HasLength != expected.HasLength || !bytes.Equal(f.DatagramData, expected.DatagramData) {
+			t.Fatalf("Expected %v but got %v", expected, f)
+		}
+	}
+
+	if _, frameValue, qerr := Frame.ParseFrames([]byte{0x31, 0x05, 'a'}); qerr != QuicErr.FRAME_ENCODING_ERROR {
+		t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+	} else if frameValue != 0x31 {
+		t.Fatal("Expected frame type 0x31 but got", frameValue)
+	}
+}
@@ -0,0 +1,110 @@
+package Frame
+
+import (
+	"bufio"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+/*
+PADDING Frame {
+  Type (i) = 0x00,
+}
+*/
+
+// A PADDING frame has no semantic value.
+// PADDING frames can be used to increase the size of a packet.
+//
+// Consecutive PADDING frames are coalesced into a single PaddingFrame.
+type PaddingFrame struct {
+	// Number of consecutive PADDING frames. A Length less than 1 is considered 1.
+	Length int
+}
+
+func (f *PaddingFrame) FrameValue() varint.Int62 {
+	return 0x00
+}
+
+func (f *PaddingFrame) Len() int {
+	return max(f.Length, 1)
+}
+
+func (f *PaddingFrame) Append(b []byte) ([]byte, error) {
+	return append(b, make([]byte, f.Len())...), nil
+}
+
+// ReadPaddingFrame reads every consecutive PADDING frame.
+func ReadPaddingFrame(rd *bufio.Reader) (PaddingFrame, QuicErr.Err) {
+	f := PaddingFrame{}
+	if _, qerr := readFrameValue(rd, 0x00, 0x00); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.Length = 1
+
+	for {
+		b, _ := rd.Peek(max(rd.Buffered(), 1))
+		n := 0
+		for n < len(b) && b[n] == 0x00 {
+			n++
+		}
+		rd.Discard(n)
+		f.Length += n
+
+		if n < len(b) || n == 0 {
+			return f, QuicErr.NO_ERROR
+		}
+	}
+}
+
+/*
+PING Frame {
+  Type (i) = 0x01,
+}
+*/
+
+// Endpoints can use PING frames to verify that their peers are still alive or to check reachability to the peer.
+type PingFrame struct{}
+
+func (f *PingFrame) FrameValue() varint.Int62 {
+	return 0x01
+}
+
+func (f *PingFrame) Len() int {
+	return 1
+}
+
+func (f *PingFrame) Append(b []byte) ([]byte, error) {
+	return append(b, 0x01), nil
+}
+
+func ReadPingFrame(rd *bufio.Reader) (PingFrame, QuicErr.Err) {
+	_, qerr := readFrameValue(rd, 0x01, 0x01)
+	return PingFrame{}, qerr
+}
+
+/*
+HANDSHAKE_DONE Frame {
+  Type (i) = 0x1e,
+}
+*/
+
+// The server uses a HANDSHAKE_DONE frame to signal confirmation of the handshake to the client.
+type HandshakeDoneFrame struct{}
+
+func (f *HandshakeDoneFrame) FrameValue() varint.Int62 {
+	return 0x1e
+}
+
+func (f *HandshakeDoneFrame) Len() int {
+	return 1
+}
+
+func (f *HandshakeDoneFrame) Append(b []byte) ([]byte, error) {
+	return append(b, 0x1e), nil
+}
+
+func ReadHandshakeDoneFrame(rd *bufio.Reader) (HandshakeDoneFrame, QuicErr.Err) {
+	_, qerr := readFrameValue(rd, 0x1e, 0x1e)
+	return HandshakeDoneFrame{}, qerr
+}
@@ -0,0 +1,67 @@
+package Frame_test
+
+import (
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+)
+
+func TestPaddingFrameCoalescing(t *testing.T) {
+	// A 1200 bytes long padded Initial packet payload.
+	payload := append([]byte{0x01}, make([]byte, 1199)...)
+
+	frames, _, qerr := Frame.ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if len(frames) != 2 {
+		t.Fatal("Expected 2 frames but got", len(frames))
+	}
+
+	padding, ok := frames[1].(*Frame.PaddingFrame)
+	if !ok {
+		t.Fatalf("Expected a *Frame.PaddingFrame but got %T", frames[1])
+	} else if padding.Length != 1199 || padding.Len() != 1199 {
+		t.Fatal("Expected 1199 PADDING frames but got", padding.Length)
+	}
+
+	b, err := Frame.Encode(padding)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != 1199 {
+		t.Fatal("Expected 1199 bytes but got", len(b))
+	}
+
+	// PADDING frames are coalesced only until the next frame.
+	frames, _, qerr = Frame.ParseFrames([]byte{0x00, 0x00, 0x01, 0x00, 0x1e})
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if len(frames) != 4 {
+		t.Fatal("Expected 4 frames but got", len(frames))
+	} else if frames[0].Len() != 2 || frames[2].Len() != 1 {
+		t.Fatal("Expected PADDING frames of length 2 and 1 but got", frames[0].Len(), "and", frames[2].Len())
+	}
+}
+
+func TestEmptyFramesEncodeAndDecode(t *testing.T) {
+	input := []Frame.Frame{&Frame.PingFrame{}, &Frame.HandshakeDoneFrame{}, &Frame.PaddingFrame{}}
+	payload := make([]byte, 0)
+	for _, f := range input {
+		b, err := f.Append(payload)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		payload = b
+	}
+
+	if string(payload) != string([]byte{0x01, 0x1e, 0x00}) {
+		t.Fatal("Unexpected payload", payload)
+	}
+
+	frames, _, qerr := Frame.ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if len(frames) != len(input) {
+		t.Fatal("Expected", len(input), "frames but got", len(frames))
+	}
+}
@@ -0,0 +1,282 @@
+package Frame
+
+import (
+	"bufio"
+	"errors"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	StreamIdentifier "github.com/udan-jayanith/Quick/stream-identifier"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+const (
+	// Maximum Streams and the stream limit of STREAMS_BLOCKED frames cannot exceed 2^60,
+	// as it is not possible to encode stream IDs larger than 2^62-1.
+	MaxStreamsLimit varint.Int62 = 1 << 60
+)
+
+var (
+	StreamsLimitExceeded error = errors.New("Maximum Streams cannot exceed 2^60")
+)
+
+/*
+MAX_DATA Frame {
+  Type (i) = 0x10,
+  Maximum Data (i),
+}
+*/
+
+// A MAX_DATA frame is used in flow control to inform the peer of the maximum amount of data that can be sent on the connection as a whole.
+type MaxDataFrame struct {
+	// Maximum amount of data that can be sent on the entire connection, in units of bytes.
+	MaximumData varint.Int62
+}
+
+func (f *MaxDataFrame) FrameValue() varint.Int62 {
+	return 0x10
+}
+
+func (f *MaxDataFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.MaximumData)
+}
+
+func (f *MaxDataFrame) Append(b []byte) ([]byte, error) {
+	return appendVarints(b, f.FrameValue(), f.MaximumData)
+}
+
+func ReadMaxDataFrame(rd *bufio.Reader) (MaxDataFrame, QuicErr.Err) {
+	f := MaxDataFrame{}
+	if _, qerr := readFrameValue(rd, 0x10, 0x10); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	var qerr QuicErr.Err
+	f.MaximumData, qerr = readVarint(rd)
+	return f, qerr
+}
+
+/*
+MAX_STREAM_DATA Frame {
+  Type (i) = 0x11,
+  Stream ID (i),
+  Maximum Stream Data (i),
+}
+*/
+
+// A MAX_STREAM_DATA frame is used in flow control to inform a peer of the maximum amount of data that can be sent on a stream.
+type MaxStreamDataFrame struct {
+	StreamID StreamIdentifier.StreamID
+	// Maximum amount of data that can be sent on the identified stream, in units of bytes.
+	MaximumStreamData varint.Int62
+}
+
+func (f *MaxStreamDataFrame) FrameValue() varint.Int62 {
+	return 0x11
+}
+
+func (f *MaxStreamDataFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.StreamID.ToInt62()) + varint.VarintLength(f.MaximumStreamData)
+}
+
+func (f *MaxStreamDataFrame) Append(b []byte) ([]byte, error) {
+	return appendVarints(b, f.FrameValue(), f.StreamID.ToInt62(), f.MaximumStreamData)
+}
+
+func ReadMaxStreamDataFrame(rd *bufio.Reader) (MaxStreamDataFrame, QuicErr.Err) {
+	f := MaxStreamDataFrame{}
+	if _, qerr := readFrameValue(rd, 0x11, 0x11); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	streamID, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.StreamID = StreamIdentifier.NewStreamID(streamID)
+
+	f.MaximumStreamData, qerr = readVarint(rd)
+	return f, qerr
+}
+
+/*
+MAX_STREAMS Frame {
+  Type (i) = 0x12..0x13,
+  Maximum Streams (i),
+}
+*/
+
+// A MAX_STREAMS frame informs the peer of the cumulative number of streams of a given type it is permitted to open.
+// A MAX_STREAMS frame with a type of 0x12 applies to bidirectional streams, and a MAX_STREAMS frame with a type of 0x13 applies to unidirectional streams.
+type MaxStreamsFrame struct {
+	Bidirectional bool
+	// A count of the cumulative number of streams of the corresponding type that can be opened over the lifetime of the connection.
+	// MaximumStreams cannot exceed MaxStreamsLimit.
+	MaximumStreams varint.Int62
+}
+
+func (f *MaxStreamsFrame) FrameValue() varint.Int62 {
+	if f.Bidirectional {
+		return 0x12
+	}
+	return 0x13
+}
+
+func (f *MaxStreamsFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.MaximumStreams)
+}
+
+// Append appends the encoded MaxStreamsFrame to b.
+// If the MaximumStreams exceeds MaxStreamsLimit Append returns b and StreamsLimitExceeded.
+func (f *MaxStreamsFrame) Append(b []byte) ([]byte, error) {
+	if f.MaximumStreams > MaxStreamsLimit {
+		return b, StreamsLimitExceeded
+	}
+	return appendVarints(b, f.FrameValue(), f.MaximumStreams)
+}
+
+func ReadMaxStreamsFrame(rd *bufio.Reader) (MaxStreamsFrame, QuicErr.Err) {
+	f := MaxStreamsFrame{}
+	frameValue, qerr := readFrameValue(rd, 0x12, 0x13)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.Bidirectional = frameValue == 0x12
+
+	if f.MaximumStreams, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	} else if f.MaximumStreams > MaxStreamsLimit {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return f, QuicErr.NO_ERROR
+}
+
+/*
+DATA_BLOCKED Frame {
+  Type (i) = 0x14,
+  Maximum Data (i),
+}
+*/
+
+// A sender SHOULD send a DATA_BLOCKED frame when it wishes to send data but is unable to do so due to connection-level flow control.
+type DataBlockedFrame struct {
+	// Connection-level limit at which blocking occurred.
+	MaximumData varint.Int62
+}
+
+func (f *DataBlockedFrame) FrameValue() varint.Int62 {
+	return 0x14
+}
+
+func (f *DataBlockedFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.MaximumData)
+}
+
+func (f *DataBlockedFrame) Append(b []byte) ([]byte, error) {
+	return appendVarints(b, f.FrameValue(), f.MaximumData)
+}
+
+func ReadDataBlockedFrame(rd *bufio.Reader) (DataBlockedFrame, QuicErr.Err) {
+	f := DataBlockedFrame{}
+	if _, qerr := readFrameValue(rd, 0x14, 0x14); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	var qerr QuicErr.Err
+	f.MaximumData, qerr = readVarint(rd)
+	return f, qerr
+}
+
+/*
+STREAM_DATA_BLOCKED Frame {
+  Type (i) = 0x15,
+  Stream ID (i),
+  Maximum Stream Data (i),
+}
+*/
+
+// A sender SHOULD send a STREAM_DATA_BLOCKED frame when it wishes to send data but is unable to do so due to stream-level flow control.
+type StreamDataBlockedFrame struct {
+	StreamID StreamIdentifier.StreamID
+	// Offset of the stream at which the blocking occurred.
+	MaximumStreamData varint.Int62
+}
+
+func (f *StreamDataBlockedFrame) FrameValue() varint.Int62 {
+	return 0x15
+}
+
+func (f *StreamDataBlockedFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.StreamID.ToInt62()) + varint.VarintLength(f.MaximumStreamData)
+}
+
+func (f *StreamDataBlockedFrame) Append(b []byte) ([]byte, error) {
+	return appendVarints(b, f.FrameValue(), f.StreamID.ToInt62(), f.MaximumStreamData)
+}
+
+func ReadStreamDataBlockedFrame(rd *bufio.Reader) (StreamDataBlockedFrame, QuicErr.Err) {
+	f := StreamDataBlockedFrame{}
+	if _, qerr := readFrameValue(rd, 0x15, 0x15); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	streamID, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.StreamID = StreamIdentifier.NewStreamID(streamID)
+
+	f.MaximumStreamData, qerr = readVarint(rd)
+	return f, qerr
+}
+
+/*
+STREAMS_BLOCKED Frame {
+  Type (i) = 0x16..0x17,
+  Maximum Streams (i),
+}
+*/
+
+// A sender SHOULD send a STREAMS_BLOCKED frame when it wishes to open a stream but is unable to do so due to the maximum stream limit set by its peer.
+// A STREAMS_BLOCKED frame of type 0x16 is used to indicate reaching the bidirectional stream limit, and a STREAMS_BLOCKED frame of type 0x17 is used to indicate reaching the unidirectional stream limit.
+type StreamsBlockedFrame struct {
+	Bidirectional bool
+	// Maximum number of streams allowed at the time the frame was sent.
+	// MaximumStreams cannot exceed MaxStreamsLimit.
+	MaximumStreams varint.Int62
+}
+
+func (f *StreamsBlockedFrame) FrameValue() varint.Int62 {
+	if f.Bidirectional {
+		return 0x16
+	}
+	return 0x17
+}
+
+func (f *StreamsBlockedFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.MaximumStreams)
+}
+
+// Append appends the encoded StreamsBlockedFrame to b.
+// If the MaximumStreams exceeds MaxStreamsLimit Append returns b and StreamsLimitExceeded.
+func (f *StreamsBlockedFrame) Append(b []byte) ([]byte, error) {
+	if f.MaximumStreams > MaxStreamsLimit {
+		return b, StreamsLimitExceeded
+	}
+	return appendVarints(b, f.FrameValue(), f.MaximumStreams)
+}
+
+func ReadStreamsBlockedFrame(rd *bufio.Reader) (StreamsBlockedFrame, QuicErr.Err) {
+	f := StreamsBlockedFrame{}
+	frameValue, qerr := readFrameValue(rd, 0x16, 0x17)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+	f.Bidirectional = frameValue == 0x16
+
+	if f.MaximumStreams, qerr = readVarint(rd); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	} else if f.MaximumStreams > MaxStreamsLimit {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return f, QuicErr.NO_ERROR
+}
@@ -0,0 +1,76 @@
+package Frame_test
+
+import (
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	StreamIdentifier "github.com/udan-jayanith/Quick/stream-identifier"
+)
+
+func TestFlowControlFramesEncodeAndDecode(t *testing.T) {
+	input := []Frame.Frame{
+		&Frame.MaxDataFrame{MaximumData: 1 << 30},
+		&Frame.MaxStreamDataFrame{StreamID: StreamIdentifier.NewStreamID(StreamIdentifier.ClientInitiatedUni), MaximumStreamData: 65535},
+		&Frame.MaxStreamsFrame{Bidirectional: true, MaximumStreams: Frame.MaxStreamsLimit},
+		&Frame.MaxStreamsFrame{Bidirectional: false, MaximumStreams: 100},
+		&Frame.DataBlockedFrame{MaximumData: 1 << 30},
+		&Frame.StreamDataBlockedFrame{StreamID: StreamIdentifier.NewStreamID(StreamIdentifier.ServerInitiatedBidi), MaximumStreamData: 65535},
+		&Frame.StreamsBlockedFrame{Bidirectional: true, MaximumStreams: 10},
+		&Frame.StreamsBlockedFrame{Bidirectional: false, MaximumStreams: 0},
+	}
+	frameValues := [...]byte{0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17}
+
+	payload := make([]byte, 0)
+	for i, f := range input {
+		b, err := Frame.Encode(f)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(b) != f.Len() {
+			t.Fatal("Expected", f.Len(), "bytes but got", len(b), "at", i)
+		} else if b[0] != frameValues[i] {
+			t.Fatalf("Expected frame type %x but got %x at %v", frameValues[i], b[0], i)
+		}
+		payload = append(payload, b...)
+	}
+
+	frames, _, qerr := Frame.ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if len(frames) != len(input) {
+		t.Fatal("Expected", len(input), "frames but got", len(frames))
+	}
+
+	for i, f := range frames {
+		b1, _ := Frame.Encode(input[i])
+		b2, err := Frame.Encode(f)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if string(b1) != string(b2) {
+			t.Fatalf("Expected %v but got %v at %v", b1, b2, i)
+		}
+	}
+}
+
+func TestMaxStreamsLimit(t *testing.T) {
+	for _, f := range []Frame.Frame{
+		&Frame.MaxStreamsFrame{MaximumStreams: Frame.MaxStreamsLimit + 1},
+		&Frame.StreamsBlockedFrame{MaximumStreams: Frame.MaxStreamsLimit + 1},
+	} {
+		if _, err := Frame.Encode(f); err != Frame.StreamsLimitExceeded {
+			t.Fatal("Expected", Frame.StreamsLimitExceeded, "but got", err)
+		}
+	}
+
+	// 2^60+1 encoded as a 8 byte variable length integer.
+	for _, payload := range [][]byte{
+		{0x12, 0xd0, 0, 0, 0, 0, 0, 0, 0x01},
+		{0x17, 0xd0, 0, 0, 0, 0, 0, 0, 0x01},
+	} {
+		if _, frameValue, qerr := Frame.ParseFrames(payload); qerr != QuicErr.FRAME_ENCODING_ERROR {
+			t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+		} else if byte(frameValue) != payload[0] {
+			t.Fatal("Expected frame type", payload[0], "but got", frameValue)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/udan-jayanith/Quick/varint"
 )
 
-type FrameType uint8
+// FrameType is the type of a frame. A FrameType may cover more than one frame type value, like the Stream covers 0x08-0x0f.
+// Frame types of extension frames are allocated by RegisterFrameType.
+type FrameType varint.Int62
 
 const (
 	//0x00
@@ -50,9 +52,13 @@ const (
 	ConnectionClose
 	//0x1e
 	HandshakeDone
+	//0x30-0x31
+	//
+	//https://datatracker.ietf.org/doc/html/rfc9221#section-4
+	Datagram
 )
 
-func FrameValueToType(frameValue byte) (FrameType, QuicErr.Err) {
+func FrameValueToType(frameValue varint.Int62) (FrameType, QuicErr.Err) {
 	if frameValue == 0{
 		return Padding, QuicErr.NO_ERROR
 	}else if frameValue == 1{
@@ -93,22 +99,24 @@ func FrameValueToType(frameValue byte) (FrameType, QuicErr.Err) {
 		return ConnectionClose, QuicErr.NO_ERROR
 	}else if frameValue == 0x1e{
 		return HandshakeDone, QuicErr.NO_ERROR
+	}else if frameValue >= 0x30 && frameValue <= 0x31 {
+		return Datagram, QuicErr.NO_ERROR
 	}
-	return 0, QuicErr.FRAME_ENCODING_ERROR
+	return registeredFrameType(frameValue)
 }
 
 // ReadFrameType returns the type of the frame, frame type value and an quick error.
 // If any error occurred ReadFrameType returns 0, 0 and a error.
-func ReadFrameType(rd *bufio.Reader) (FrameType, uint8, QuicErr.Err){
+func ReadFrameType(rd *bufio.Reader) (FrameType, varint.Int62, QuicErr.Err){
 	frameValue, err := varint.ReadVarint62(rd)
 	if err != nil {
 		return 0, 0, QuicErr.FRAME_ENCODING_ERROR
 	}
 
-	frameType, qerr := FrameValueToType(byte(frameValue))
+	frameType, qerr := FrameValueToType(frameValue)
 	if qerr != QuicErr.NO_ERROR {
 		return 0, 0, qerr
 	}
 
-	return frameType, uint8(frameValue), QuicErr.NO_ERROR
+	return frameType, frameValue, QuicErr.NO_ERROR
 }
\ No newline at end of file
@@ -8,11 +8,12 @@ import (
 	QuicErr "github.com/udan-jayanith/Quick/errors"
 	Frame "github.com/udan-jayanith/Quick/frames"
 	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	"github.com/udan-jayanith/Quick/varint"
 )
 
 var (
 	frameValueToTypeTestcases = [...]struct {
-		FrameValue byte
+		FrameValue varint.Int62
 		FrameType  Frame.FrameType
 		Qerr       QuicErr.Err
 	}{
@@ -52,11 +53,22 @@ var (
 			FrameType:  Frame.HandshakeDone,
 			Qerr:       QuicErr.NO_ERROR,
 		},
+		{
+			FrameValue: 0x31,
+			FrameType:  Frame.Datagram,
+			Qerr:       QuicErr.NO_ERROR,
+		},
 		{
 			FrameValue: 0x1e + 1,
 			FrameType:  0,
 			Qerr:       QuicErr.FRAME_ENCODING_ERROR,
 		},
+		{
+			// byte(0x102) == 0x02 must not be mistaken for a ACK frame.
+			FrameValue: 0x102,
+			FrameType:  0,
+			Qerr:       QuicErr.FRAME_ENCODING_ERROR,
+		},
 	}
 )
 
@@ -74,7 +86,7 @@ func TestFrameValueToType(t *testing.T) {
 var (
 	readFrameTypeTestcases = [...]struct {
 		FrameType  Frame.FrameType
-		FrameValue uint8
+		FrameValue varint.Int62
 		Err        QuicErr.Err
 		Input      []byte
 	}{
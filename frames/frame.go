@@ -0,0 +1,224 @@
+package Frame
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	StreamFrame "github.com/udan-jayanith/Quick/frames/stream-frame"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+// Frame is implemented by every QUIC frame.
+type Frame interface {
+	// FrameValue returns the frame type value as it's encoded on the wire.
+	FrameValue() varint.Int62
+	// Append appends the encoded frame to b and returns the extended buffer.
+	// If Append returns a error b is returned unchanged.
+	Append(b []byte) ([]byte, error)
+	// Len returns the number of bytes Append appends.
+	Len() int
+}
+
+// Encode returns the encoded frame.
+func Encode(f Frame) ([]byte, error) {
+	return f.Append(make([]byte, 0, f.Len()))
+}
+
+// FrameParser reads a frame including the frame type from rd.
+type FrameParser func(rd *bufio.Reader) (Frame, QuicErr.Err)
+
+var (
+	frameParsers = map[FrameType]FrameParser{
+		Padding: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadPaddingFrame(rd)
+			return &f, err
+		},
+		Ping: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadPingFrame(rd)
+			return &f, err
+		},
+		Ack: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadAckFrame(rd)
+			return &f, err
+		},
+		ResetStream: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := StreamFrame.ReadResetStreamFrame(rd)
+			return &f, err
+		},
+		StopSending: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := StreamFrame.ReadStopSendingFrame(rd)
+			return &f, err
+		},
+		Crypto: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadCryptoFrame(rd)
+			return &f, err
+		},
+		NewToken: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadNewTokenFrame(rd)
+			return &f, err
+		},
+		Stream: readStreamFrame,
+		MaxData: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadMaxDataFrame(rd)
+			return &f, err
+		},
+		MaxStreamData: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadMaxStreamDataFrame(rd)
+			return &f, err
+		},
+		MaxStreams: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadMaxStreamsFrame(rd)
+			return &f, err
+		},
+		DataBlocked: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadDataBlockedFrame(rd)
+			return &f, err
+		},
+		StreamDataBlocked: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadStreamDataBlockedFrame(rd)
+			return &f, err
+		},
+		StreamsBlocked: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadStreamsBlockedFrame(rd)
+			return &f, err
+		},
+		NewConnectionId: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadNewConnectionIDFrame(rd)
+			return &f, err
+		},
+		RetierConnectionId: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadRetireConnectionIDFrame(rd)
+			return &f, err
+		},
+		PathChallenge: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadPathChallengeFrame(rd)
+			return &f, err
+		},
+		PathResponse: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadPathResponseFrame(rd)
+			return &f, err
+		},
+		ConnectionClose: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadConnectionCloseFrame(rd)
+			return &f, err
+		},
+		HandshakeDone: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadHandshakeDoneFrame(rd)
+			return &f, err
+		},
+		Datagram: func(rd *bufio.Reader) (Frame, QuicErr.Err) {
+			f, err := ReadDatagramFrame(rd)
+			return &f, err
+		},
+	}
+)
+
+// A STREAM frame without the Length field extends to the end of the packet.
+func readStreamFrame(rd *bufio.Reader) (Frame, QuicErr.Err) {
+	sf, qerr := StreamFrame.ReadStreamFrame(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return &sf, qerr
+	}
+
+	if !sf.Type.GetLength() {
+		data, err := io.ReadAll(rd)
+		if err != nil {
+			return &sf, QuicErr.FRAME_ENCODING_ERROR
+		} else if (sf.Offset + varint.Int62(len(data))).IsOverflowing() {
+			return &sf, QuicErr.FRAME_ENCODING_ERROR
+		}
+		sf.StreamData = bytes.NewReader(data)
+	}
+	return &sf, QuicErr.NO_ERROR
+}
+
+// PeekFrameValue returns the frame type value of the next frame without advancing the reader.
+func PeekFrameValue(rd *bufio.Reader) (varint.Int62, error) {
+	b, err := rd.Peek(1)
+	if err != nil {
+		return 0, err
+	}
+
+	length := 1 << (b[0] >> 6)
+	b, err = rd.Peek(length)
+	if err != nil {
+		return 0, err
+	}
+
+	// VarintToInt62 modifies the slice. Peeked bytes must not be modified.
+	return varint.VarintToInt62(bytes.Clone(b))
+}
+
+// ParseFrames parses every frame in a decrypted packet payload.
+// If a frame is malformed ParseFrames returns the frames parsed before it, the frame type value of the malformed frame and a quic error.
+// Otherwise the frame type value is 0 and the quic error is QuicErr.NO_ERROR.
+func ParseFrames(payload []byte) ([]Frame, varint.Int62, QuicErr.Err) {
+	rd := bufio.NewReader(bytes.NewReader(payload))
+	frames := make([]Frame, 0, 4)
+
+	for {
+		if _, err := rd.Peek(1); err == io.EOF {
+			break
+		}
+
+		frameValue, err := PeekFrameValue(rd)
+		if err != nil {
+			return frames, frameValue, QuicErr.FRAME_ENCODING_ERROR
+		}
+
+		frameType, qerr := FrameValueToType(frameValue)
+		if qerr != QuicErr.NO_ERROR {
+			return frames, frameValue, qerr
+		}
+
+		parser, ok := frameParsers[frameType]
+		if !ok {
+			parser, ok = registeredFrameParser(frameType)
+		}
+		if !ok {
+			return frames, frameValue, QuicErr.FRAME_ENCODING_ERROR
+		}
+
+		f, qerr := parser(rd)
+		if qerr != QuicErr.NO_ERROR {
+			return frames, frameValue, qerr
+		}
+		frames = append(frames, f)
+	}
+
+	return frames, 0, QuicErr.NO_ERROR
+}
+
+// readFrameValue reads the frame type value and checks whether it's between min and max inclusive.
+func readFrameValue(rd *bufio.Reader, min, max varint.Int62) (varint.Int62, QuicErr.Err) {
+	v, err := varint.ReadVarint62(rd)
+	if err != nil || v < min || v > max {
+		return v, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return v, QuicErr.NO_ERROR
+}
+
+// readVarint reads a variable length integer field of a frame.
+func readVarint(rd *bufio.Reader) (varint.Int62, QuicErr.Err) {
+	v, err := varint.ReadVarint62(rd)
+	if err != nil {
+		return 0, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return v, QuicErr.NO_ERROR
+}
+
+// appendVarints appends values to b as variable length integers.
+// If any value is overflowing appendVarints returns b unchanged and varint.IntegerOverflow.
+func appendVarints(b []byte, values ...varint.Int62) ([]byte, error) {
+	buf := b
+	for _, v := range values {
+		encoded, err := varint.Int62ToVarint(v)
+		if err != nil {
+			return b, err
+		}
+		buf = append(buf, encoded...)
+	}
+	return buf, nil
+}
@@ -0,0 +1,117 @@
+package Frame_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	StreamFrame "github.com/udan-jayanith/Quick/frames/stream-frame"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	StreamIdentifier "github.com/udan-jayanith/Quick/stream-identifier"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+func TestParseFrames(t *testing.T) {
+	streamFrame := StreamFrame.StreamFrame{
+		Type:       StreamFrame.NewStreamFrameType().SetLength(true).SetOffset(true),
+		StreamID:   StreamIdentifier.NewStreamID(StreamIdentifier.ClientInitiatedBidi),
+		Offset:     1024,
+		Length:     5,
+		StreamData: bytes.NewReader([]byte("hello")),
+	}
+	// The last STREAM frame has no Length field and extends to the end of the payload.
+	lastStreamFrame := StreamFrame.StreamFrame{
+		Type:       StreamFrame.NewStreamFrameType().SetFin(true),
+		StreamID:   StreamIdentifier.NewStreamID(StreamIdentifier.ServerInitiatedUni),
+		StreamData: bytes.NewReader([]byte("world")),
+	}
+
+	input := []Frame.Frame{
+		&Frame.PingFrame{},
+		&streamFrame,
+		&Frame.HandshakeDoneFrame{},
+		&Frame.PaddingFrame{},
+		&lastStreamFrame,
+	}
+
+	payload := make([]byte, 0)
+	for _, f := range input {
+		b, err := f.Append(payload)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(b)-len(payload) != f.Len() {
+			t.Fatal("Expected", f.Len(), "bytes to be appended but", len(b)-len(payload), "were appended")
+		}
+		payload = b
+	}
+
+	frames, frameValue, qerr := Frame.ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatalf("Unexpected error '%s' at frame type %v", qerr.Error(), frameValue)
+	} else if len(frames) != len(input) {
+		t.Fatal("Expected", len(input), "frames but got", len(frames))
+	}
+
+	for i, f := range frames {
+		if f.FrameValue() != input[i].FrameValue() {
+			t.Fatalf("Expected frame type %v but got %v at %v", input[i].FrameValue(), f.FrameValue(), i)
+		}
+	}
+
+	sf, ok := frames[4].(*StreamFrame.StreamFrame)
+	if !ok {
+		t.Fatalf("Expected a *StreamFrame.StreamFrame but got %T", frames[4])
+	}
+	data, err := io.ReadAll(sf.StreamData)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if string(data) != "world" {
+		t.Fatalf("Expected stream data 'world' but got '%s'", data)
+	}
+}
+
+var (
+	parseFramesErrorTestcases = [...]struct {
+		Input      []byte
+		FrameValue varint.Int62
+		Frames     int
+		Err        QuicErr.Err
+	}{
+		{
+			// Unknown frame type.
+			Input:      []byte{0x01, 0x1f},
+			FrameValue: 0x1f,
+			Frames:     1,
+			Err:        QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// STREAM frame with a Length larger than the payload.
+			Input:      []byte{0x00, 0x0a, 0x00, 0x05, 'a'},
+			FrameValue: 0x0a,
+			Frames:     1,
+			Err:        QuicErr.FRAME_ENCODING_ERROR,
+		},
+		{
+			// Truncated frame type.
+			Input:      []byte{0x40},
+			FrameValue: 0,
+			Frames:     0,
+			Err:        QuicErr.FRAME_ENCODING_ERROR,
+		},
+	}
+)
+
+func TestParseFramesErrors(t *testing.T) {
+	for i, testcase := range parseFramesErrorTestcases {
+		frames, frameValue, qerr := Frame.ParseFrames(testcase.Input)
+		if qerr != testcase.Err {
+			t.Fatalf("Test %v failed.\nExpected error '%s' but got '%s'\n%s", i, testcase.Err.Error(), qerr.Error(), Testing.ToFormattedJson(testcase))
+		} else if frameValue != testcase.FrameValue {
+			t.Fatalf("Test %v failed.\nExpected frame type %v but got %v\n%s", i, testcase.FrameValue, frameValue, Testing.ToFormattedJson(testcase))
+		} else if len(frames) != testcase.Frames {
+			t.Fatalf("Test %v failed.\nExpected %v frames but got %v\n%s", i, testcase.Frames, len(frames), Testing.ToFormattedJson(testcase))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package Frame
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	EmptyToken error = errors.New("Token cannot be empty")
+)
+
+/*
+NEW_TOKEN Frame {
+  Type (i) = 0x07,
+  Token Length (i),
+  Token (..),
+}
+*/
+
+// A server sends a NEW_TOKEN frame to provide the client with a token to send in the header of an Initial packet for a future connection.
+type NewTokenFrame struct {
+	// An opaque blob that the client can use with a future Initial packet. The Token cannot be empty.
+	Token []byte
+}
+
+func (f *NewTokenFrame) FrameValue() varint.Int62 {
+	return 0x07
+}
+
+func (f *NewTokenFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(varint.Int62(len(f.Token))) + len(f.Token)
+}
+
+// Append appends the encoded NewTokenFrame to b.
+// If the Token is empty Append returns b and EmptyToken.
+func (f *NewTokenFrame) Append(b []byte) ([]byte, error) {
+	if len(f.Token) == 0 {
+		return b, EmptyToken
+	}
+
+	buf, err := appendVarints(b, f.FrameValue(), varint.Int62(len(f.Token)))
+	if err != nil {
+		return b, err
+	}
+	return append(buf, f.Token...), nil
+}
+
+func ReadNewTokenFrame(rd *bufio.Reader) (NewTokenFrame, QuicErr.Err) {
+	f := NewTokenFrame{}
+	if _, qerr := readFrameValue(rd, 0x07, 0x07); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	// A client MUST treat receipt of a NEW_TOKEN frame with an empty Token field as a connection error of type FRAME_ENCODING_ERROR.
+	length, qerr := readVarint(rd)
+	if qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	} else if length == 0 {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	// length is not trusted for allocation.
+	token, err := io.ReadAll(io.LimitReader(rd, int64(length)))
+	if err != nil || varint.Int62(len(token)) != length {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	f.Token = token
+	return f, QuicErr.NO_ERROR
+}
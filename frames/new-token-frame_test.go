@@ -0,0 +1,40 @@
+package Frame_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+)
+
+func TestNewTokenFrameEncodeAndDecode(t *testing.T) {
+	f := Frame.NewTokenFrame{Token: []byte("address validation token")}
+	b, err := Frame.Encode(&f)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != f.Len() {
+		t.Fatal("Expected", f.Len(), "bytes but got", len(b))
+	}
+
+	newFrame, qerr := Frame.ReadNewTokenFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if !bytes.Equal(newFrame.Token, f.Token) {
+		t.Fatalf("Expected '%s' but got '%s'", f.Token, newFrame.Token)
+	}
+
+	if _, err := Frame.Encode(&Frame.NewTokenFrame{}); err != Frame.EmptyToken {
+		t.Fatal("Expected", Frame.EmptyToken, "but got", err)
+	}
+
+	for _, input := range [][]byte{
+		{0x07, 0x00},
+		{0x07, 0x02, 0xff},
+	} {
+		if _, qerr := Frame.ReadNewTokenFrame(bufio.NewReader(bytes.NewReader(input))); qerr != QuicErr.FRAME_ENCODING_ERROR {
+			t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package Frame
+
+import (
+	"bufio"
+	"io"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+/*
+PATH_CHALLENGE Frame {
+  Type (i) = 0x1a,
+  Data (64),
+}
+*/
+
+// Endpoints can use PATH_CHALLENGE frames to check reachability to the peer and for path validation during connection migration.
+type PathChallengeFrame struct {
+	// This 8-byte field contains arbitrary data.
+	Data [8]byte
+}
+
+func (f *PathChallengeFrame) FrameValue() varint.Int62 {
+	return 0x1a
+}
+
+func (f *PathChallengeFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + len(f.Data)
+}
+
+func (f *PathChallengeFrame) Append(b []byte) ([]byte, error) {
+	return append(append(b, 0x1a), f.Data[:]...), nil
+}
+
+func ReadPathChallengeFrame(rd *bufio.Reader) (PathChallengeFrame, QuicErr.Err) {
+	f := PathChallengeFrame{}
+	if _, qerr := readFrameValue(rd, 0x1a, 0x1a); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	if _, err := io.ReadFull(rd, f.Data[:]); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return f, QuicErr.NO_ERROR
+}
+
+/*
+PATH_RESPONSE Frame {
+  Type (i) = 0x1b,
+  Data (64),
+}
+*/
+
+// A PATH_RESPONSE frame is sent in response to a PATH_CHALLENGE frame.
+type PathResponseFrame struct {
+	// Data is the data of the PATH_CHALLENGE frame being responded to.
+	Data [8]byte
+}
+
+// NewPathResponseFrame returns the PathResponseFrame that responds to the challenge.
+func NewPathResponseFrame(challenge *PathChallengeFrame) PathResponseFrame {
+	return PathResponseFrame{
+		Data: challenge.Data,
+	}
+}
+
+func (f *PathResponseFrame) FrameValue() varint.Int62 {
+	return 0x1b
+}
+
+func (f *PathResponseFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + len(f.Data)
+}
+
+func (f *PathResponseFrame) Append(b []byte) ([]byte, error) {
+	return append(append(b, 0x1b), f.Data[:]...), nil
+}
+
+func ReadPathResponseFrame(rd *bufio.Reader) (PathResponseFrame, QuicErr.Err) {
+	f := PathResponseFrame{}
+	if _, qerr := readFrameValue(rd, 0x1b, 0x1b); qerr != QuicErr.NO_ERROR {
+		return f, qerr
+	}
+
+	if _, err := io.ReadFull(rd, f.Data[:]); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	return f, QuicErr.NO_ERROR
+}
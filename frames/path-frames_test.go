@@ -0,0 +1,44 @@
+package Frame_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+)
+
+func TestPathFramesEncodeAndDecode(t *testing.T) {
+	challenge := Frame.PathChallengeFrame{Data: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}}
+	b, err := Frame.Encode(&challenge)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != 9 || len(b) != challenge.Len() {
+		t.Fatal("Expected 9 bytes but got", len(b))
+	}
+
+	newChallenge, qerr := Frame.ReadPathChallengeFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newChallenge != challenge {
+		t.Fatal("Expected", challenge, "but got", newChallenge)
+	}
+
+	response := Frame.NewPathResponseFrame(&newChallenge)
+	if b, err = Frame.Encode(&response); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	newResponse, qerr := Frame.ReadPathResponseFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newResponse.Data != challenge.Data {
+		t.Fatal("Expected", challenge.Data, "but got", newResponse.Data)
+	}
+
+	// Data shorter than 8 bytes.
+	if _, qerr := Frame.ReadPathResponseFrame(bufio.NewReader(bytes.NewReader(b[:8]))); qerr != QuicErr.FRAME_ENCODING_ERROR {
+		t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+	}
+}
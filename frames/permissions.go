@@ -0,0 +1,201 @@
+package Frame
+
+import (
+	"errors"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	BuiltinFrameType      error = errors.New("Properties of built in frame types cannot be changed")
+	UnregisteredFrameType error = errors.New("Frame type is not registered")
+)
+
+// PacketTypes is a set of packet types a frame can appear in.
+type PacketTypes uint8
+
+const (
+	InitialPackets PacketTypes = 1 << iota
+	ZeroRTTPackets
+	HandshakePackets
+	OneRTTPackets
+)
+
+func (pts PacketTypes) Contains(packetType Packet.PacketType) bool {
+	switch packetType {
+	case Packet.Initial:
+		return pts&InitialPackets != 0
+	case Packet.ZeroRTT:
+		return pts&ZeroRTTPackets != 0
+	case Packet.Handshake:
+		return pts&HandshakePackets != 0
+	case Packet.OneRTT:
+		return pts&OneRTTPackets != 0
+	}
+	return false
+}
+
+// FrameProperties are the properties of a frame type as in Table 3 of RFC 9000.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-12.4
+type FrameProperties struct {
+	PacketTypes PacketTypes
+	// Only servers can send the frame.
+	ServerOnly bool
+	// N: Packets containing only frames with this marking are not ack-eliciting.
+	NotAckEliciting bool
+	// C: Packets containing only frames with this marking do not count toward bytes in flight for congestion control purposes.
+	NotCongestionControlled bool
+	// P: Packets containing only frames with this marking can be used to probe new network paths during connection migration.
+	Probing bool
+	// F: The contents of frames with this marking are flow controlled.
+	FlowControlled bool
+}
+
+const (
+	allPacketTypes = InitialPackets | ZeroRTTPackets | HandshakePackets | OneRTTPackets
+	appPacketTypes = ZeroRTTPackets | OneRTTPackets
+)
+
+var (
+	frameProperties = map[FrameType]FrameProperties{
+		Padding:            {PacketTypes: allPacketTypes, NotAckEliciting: true, Probing: true},
+		Ping:               {PacketTypes: allPacketTypes},
+		Ack:                {PacketTypes: InitialPackets | HandshakePackets | OneRTTPackets, NotAckEliciting: true, NotCongestionControlled: true},
+		ResetStream:        {PacketTypes: appPacketTypes},
+		StopSending:        {PacketTypes: appPacketTypes},
+		Crypto:             {PacketTypes: InitialPackets | HandshakePackets | OneRTTPackets},
+		NewToken:           {PacketTypes: OneRTTPackets, ServerOnly: true},
+		Stream:             {PacketTypes: appPacketTypes, FlowControlled: true},
+		MaxData:            {PacketTypes: appPacketTypes},
+		MaxStreamData:      {PacketTypes: appPacketTypes},
+		MaxStreams:         {PacketTypes: appPacketTypes},
+		DataBlocked:        {PacketTypes: appPacketTypes},
+		StreamDataBlocked:  {PacketTypes: appPacketTypes},
+		StreamsBlocked:     {PacketTypes: appPacketTypes},
+		NewConnectionId:    {PacketTypes: appPacketTypes, Probing: true},
+		RetierConnectionId: {PacketTypes: OneRTTPackets},
+		PathChallenge:      {PacketTypes: appPacketTypes, Probing: true},
+		PathResponse:       {PacketTypes: OneRTTPackets, Probing: true},
+		// CONNECTION_CLOSE frames of type 0x1d are not allowed in Initial and Handshake packets.
+		ConnectionClose: {PacketTypes: allPacketTypes, NotAckEliciting: true},
+		HandshakeDone:   {PacketTypes: OneRTTPackets, ServerOnly: true},
+		//https://datatracker.ietf.org/doc/html/rfc9221#section-4
+		Datagram: {PacketTypes: appPacketTypes},
+	}
+	// Extension frames without properties can only appear in 0-RTT and 1-RTT packets.
+	defaultFrameProperties = FrameProperties{PacketTypes: appPacketTypes}
+)
+
+// Properties returns the FrameProperties of the frame type.
+func Properties(frameType FrameType) FrameProperties {
+	if properties, ok := frameProperties[frameType]; ok {
+		return properties
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if properties, ok := extensionFrameProperties[frameType]; ok {
+		return properties
+	}
+	return defaultFrameProperties
+}
+
+// SetFrameProperties sets the FrameProperties of a extension frame type returned by RegisterFrameType.
+// If frameType is a built in frame type SetFrameProperties returns BuiltinFrameType and if it was not returned by RegisterFrameType it returns UnregisteredFrameType.
+func SetFrameProperties(frameType FrameType, properties FrameProperties) error {
+	if frameType <= Datagram {
+		return BuiltinFrameType
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if frameType >= nextFrameType {
+		return UnregisteredFrameType
+	}
+	extensionFrameProperties[frameType] = properties
+	return nil
+}
+
+func propertiesOf(f Frame) FrameProperties {
+	frameType, qerr := FrameValueToType(f.FrameValue())
+	if qerr != QuicErr.NO_ERROR {
+		return defaultFrameProperties
+	}
+	return Properties(frameType)
+}
+
+// IsAckEliciting reports whether f elicits an acknowledgment.
+func IsAckEliciting(f Frame) bool {
+	return !propertiesOf(f).NotAckEliciting
+}
+
+// IsCongestionControlled reports whether f counts toward bytes in flight.
+func IsCongestionControlled(f Frame) bool {
+	return !propertiesOf(f).NotCongestionControlled
+}
+
+// IsProbing reports whether f is a probing frame.
+func IsProbing(f Frame) bool {
+	return propertiesOf(f).Probing
+}
+
+// IsAckElicitingPacket reports whether a packet containing frames is ack-eliciting.
+func IsAckElicitingPacket(frames []Frame) bool {
+	for _, f := range frames {
+		if IsAckEliciting(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProbingPacket reports whether a packet containing frames is a probing packet.
+// A packet containing only probing frames is a probing packet.
+func IsProbingPacket(frames []Frame) bool {
+	for _, f := range frames {
+		if !IsProbing(f) {
+			return false
+		}
+	}
+	return len(frames) != 0
+}
+
+// IsPermitted reports whether a endpoint of the role can receive the frame type value in a packet of the packetType.
+func IsPermitted(frameValue varint.Int62, packetType Packet.PacketType, role Packet.Role) bool {
+	frameType, qerr := FrameValueToType(frameValue)
+	if qerr != QuicErr.NO_ERROR {
+		return false
+	}
+
+	properties := Properties(frameType)
+	if !properties.PacketTypes.Contains(packetType) {
+		return false
+	} else if properties.ServerOnly && role == Packet.Server {
+		return false
+	} else if frameValue == 0x1d && (packetType == Packet.Initial || packetType == Packet.Handshake) {
+		return false
+	}
+	return true
+}
+
+// ParsePacketFrames parses every frame in a decrypted payload of a packet of the packetType received by a endpoint of the role.
+// In addition to the errors of ParseFrames, ParsePacketFrames returns QuicErr.PROTOCOL_VIOLATION if a frame is not permitted in the packet
+// or the packet contains no frames.
+func ParsePacketFrames(payload []byte, packetType Packet.PacketType, role Packet.Role) ([]Frame, varint.Int62, QuicErr.Err) {
+	frames, frameValue, qerr := ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		return frames, frameValue, qerr
+	} else if len(frames) == 0 {
+		return frames, 0, QuicErr.PROTOCOL_VIOLATION
+	}
+
+	for i, f := range frames {
+		if !IsPermitted(f.FrameValue(), packetType, role) {
+			return frames[:i], f.FrameValue(), QuicErr.PROTOCOL_VIOLATION
+		}
+	}
+	return frames, 0, QuicErr.NO_ERROR
+}
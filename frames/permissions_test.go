@@ -0,0 +1,129 @@
+package Frame_test
+
+import (
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	parsePacketFramesTestcases = [...]struct {
+		Payload    []byte
+		PacketType Packet.PacketType
+		Role       Packet.Role
+		FrameValue varint.Int62
+		Err        QuicErr.Err
+	}{
+		{
+			// PING and CRYPTO frames in a Initial packet.
+			Payload:    []byte{0x01, 0x06, 0x00, 0x01, 0xff},
+			PacketType: Packet.Initial,
+			Role:       Packet.Server,
+			Err:        QuicErr.NO_ERROR,
+		},
+		{
+			// STREAM frame in a Initial packet.
+			Payload:    []byte{0x01, 0x08, 0x00, 0xff},
+			PacketType: Packet.Initial,
+			Role:       Packet.Server,
+			FrameValue: 0x08,
+			Err:        QuicErr.PROTOCOL_VIOLATION,
+		},
+		{
+			// ACK frame in a 0-RTT packet.
+			Payload:    []byte{0x02, 0x00, 0x00, 0x00, 0x00},
+			PacketType: Packet.ZeroRTT,
+			Role:       Packet.Server,
+			FrameValue: 0x02,
+			Err:        QuicErr.PROTOCOL_VIOLATION,
+		},
+		{
+			// HANDSHAKE_DONE frame received by a client.
+			Payload:    []byte{0x1e},
+			PacketType: Packet.OneRTT,
+			Role:       Packet.Client,
+			Err:        QuicErr.NO_ERROR,
+		},
+		{
+			// HANDSHAKE_DONE frame received by a server.
+			Payload:    []byte{0x1e},
+			PacketType: Packet.OneRTT,
+			Role:       Packet.Server,
+			FrameValue: 0x1e,
+			Err:        QuicErr.PROTOCOL_VIOLATION,
+		},
+		{
+			// Application CONNECTION_CLOSE frame in a Handshake packet.
+			Payload:    []byte{0x1d, 0x00, 0x00},
+			PacketType: Packet.Handshake,
+			Role:       Packet.Client,
+			FrameValue: 0x1d,
+			Err:        QuicErr.PROTOCOL_VIOLATION,
+		},
+		{
+			// Transport CONNECTION_CLOSE frame in a Handshake packet.
+			Payload:    []byte{0x1c, 0x00, 0x00, 0x00},
+			PacketType: Packet.Handshake,
+			Role:       Packet.Client,
+			Err:        QuicErr.NO_ERROR,
+		},
+		{
+			// Packet without frames.
+			Payload:    []byte{},
+			PacketType: Packet.OneRTT,
+			Role:       Packet.Client,
+			Err:        QuicErr.PROTOCOL_VIOLATION,
+		},
+	}
+)
+
+func TestParsePacketFrames(t *testing.T) {
+	for i, testcase := range parsePacketFramesTestcases {
+		_, frameValue, qerr := Frame.ParsePacketFrames(testcase.Payload, testcase.PacketType, testcase.Role)
+		if qerr != testcase.Err {
+			t.Fatalf("Test %v failed.\nExpected error '%s' but got '%s'\n%s", i, testcase.Err.Error(), qerr.Error(), Testing.ToFormattedJson(testcase))
+		} else if frameValue != testcase.FrameValue {
+			t.Fatalf("Test %v failed.\nExpected frame type %v but got %v\n%s", i, testcase.FrameValue, frameValue, Testing.ToFormattedJson(testcase))
+		}
+	}
+}
+
+func TestFrameProperties(t *testing.T) {
+	ack := Frame.AckFrame{}
+	padding := Frame.PaddingFrame{}
+	challenge := Frame.PathChallengeFrame{}
+	ping := Frame.PingFrame{}
+
+	if Frame.IsAckEliciting(&ack) || Frame.IsCongestionControlled(&ack) {
+		t.Fatal("ACK frames are not ack-eliciting and not congestion controlled")
+	} else if !Frame.IsAckEliciting(&ping) || !Frame.IsCongestionControlled(&ping) {
+		t.Fatal("PING frames are ack-eliciting and congestion controlled")
+	}
+
+	if Frame.IsAckElicitingPacket([]Frame.Frame{&ack, &padding}) {
+		t.Fatal("A packet with ACK and PADDING frames is not ack-eliciting")
+	} else if !Frame.IsAckElicitingPacket([]Frame.Frame{&ack, &ping}) {
+		t.Fatal("A packet with a PING frame is ack-eliciting")
+	}
+
+	if !Frame.IsProbingPacket([]Frame.Frame{&challenge, &padding}) {
+		t.Fatal("A packet with PATH_CHALLENGE and PADDING frames is a probing packet")
+	} else if Frame.IsProbingPacket([]Frame.Frame{&challenge, &ping}) {
+		t.Fatal("A packet with a PING frame is not a probing packet")
+	}
+
+	if !Frame.Properties(Frame.Stream).FlowControlled {
+		t.Fatal("STREAM frames are flow controlled")
+	}
+
+	if err := Frame.SetFrameProperties(Frame.Ping, Frame.FrameProperties{}); err != Frame.BuiltinFrameType {
+		t.Fatal("Expected", Frame.BuiltinFrameType, "but got", err)
+	}
+	if err := Frame.SetFrameProperties(Frame.Datagram+100, Frame.FrameProperties{}); err != Frame.UnregisteredFrameType {
+		t.Fatal("Expected", Frame.UnregisteredFrameType, "but got", err)
+	}
+}
@@ -0,0 +1,98 @@
+package Frame
+
+import (
+	"errors"
+	"sync"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	InvalidFrameValueRange error = errors.New("Frame type value range is invalid")
+	FrameValueAlreadyInUse error = errors.New("Frame type value is already in use")
+)
+
+var (
+	builtinFrameValueRanges = [...]frameValueRange{{min: 0x00, max: 0x1e}, {min: 0x30, max: 0x31}}
+
+	registryMu    sync.RWMutex
+	registrations []registration
+	nextFrameType FrameType = Datagram + 1
+	// Set by SetFrameProperties.
+	extensionFrameProperties = map[FrameType]FrameProperties{}
+)
+
+type frameValueRange struct {
+	min, max varint.Int62
+}
+
+func (r frameValueRange) overlaps(other frameValueRange) bool {
+	return r.min <= other.max && other.min <= r.max
+}
+
+type registration struct {
+	frameValueRange
+	frameType FrameType
+	parser    FrameParser
+}
+
+// RegisterFrameType registers a extension frame that covers the frame type values from min to max inclusive.
+// parser is used by ParseFrames to read the frame and the Frame returned by the parser encodes the frame.
+// RegisterFrameType returns the FrameType allocated for the extension frame.
+//
+// Extension packages should call RegisterFrameType from an init function.
+// If the range is invalid RegisterFrameType returns InvalidFrameValueRange and if any value in the range is already in use it returns FrameValueAlreadyInUse.
+func RegisterFrameType(min, max varint.Int62, parser FrameParser) (FrameType, error) {
+	r := frameValueRange{min: min, max: max}
+	if min > max || max.IsOverflowing() || parser == nil {
+		return 0, InvalidFrameValueRange
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, builtin := range builtinFrameValueRanges {
+		if builtin.overlaps(r) {
+			return 0, FrameValueAlreadyInUse
+		}
+	}
+	for _, reg := range registrations {
+		if reg.overlaps(r) {
+			return 0, FrameValueAlreadyInUse
+		}
+	}
+
+	frameType := nextFrameType
+	nextFrameType++
+	registrations = append(registrations, registration{
+		frameValueRange: r,
+		frameType:       frameType,
+		parser:          parser,
+	})
+	return frameType, nil
+}
+
+func registeredFrameType(frameValue varint.Int62) (FrameType, QuicErr.Err) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, reg := range registrations {
+		if frameValue >= reg.min && frameValue <= reg.max {
+			return reg.frameType, QuicErr.NO_ERROR
+		}
+	}
+	return 0, QuicErr.FRAME_ENCODING_ERROR
+}
+
+func registeredFrameParser(frameType FrameType) (FrameParser, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, reg := range registrations {
+		if reg.frameType == frameType {
+			return reg.parser, true
+		}
+	}
+	return nil, false
+}
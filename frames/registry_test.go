@@ -0,0 +1,86 @@
+package Frame_test
+
+import (
+	"bufio"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+// ackFrequencyFrame is a simplified ACK_FREQUENCY extension frame.
+type ackFrequencyFrame struct {
+	SequenceNumber varint.Int62
+}
+
+func (f *ackFrequencyFrame) FrameValue() varint.Int62 {
+	return 0xaf
+}
+
+func (f *ackFrequencyFrame) Len() int {
+	return varint.VarintLength(f.FrameValue()) + varint.VarintLength(f.SequenceNumber)
+}
+
+func (f *ackFrequencyFrame) Append(b []byte) ([]byte, error) {
+	b = append(b, Testing.Int62ToVarint(f.FrameValue())...)
+	return append(b, Testing.Int62ToVarint(f.SequenceNumber)...), nil
+}
+
+func readAckFrequencyFrame(rd *bufio.Reader) (Frame.Frame, QuicErr.Err) {
+	f := ackFrequencyFrame{}
+	if v, err := varint.ReadVarint62(rd); err != nil || v != 0xaf {
+		return &f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	v, err := varint.ReadVarint62(rd)
+	if err != nil {
+		return &f, QuicErr.FRAME_ENCODING_ERROR
+	}
+	f.SequenceNumber = v
+	return &f, QuicErr.NO_ERROR
+}
+
+func TestRegisterFrameType(t *testing.T) {
+	frameType, err := Frame.RegisterFrameType(0xaf, 0xaf, readAckFrequencyFrame)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if ft, qerr := Frame.FrameValueToType(0xaf); qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if ft != frameType {
+		t.Fatal("Expected frame type", frameType, "but got", ft)
+	}
+
+	payload, err := Frame.Encode(&ackFrequencyFrame{SequenceNumber: 7})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	payload = append([]byte{0x01}, payload...)
+
+	frames, _, qerr := Frame.ParseFrames(payload)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if len(frames) != 2 {
+		t.Fatal("Expected 2 frames but got", len(frames))
+	} else if f, ok := frames[1].(*ackFrequencyFrame); !ok || f.SequenceNumber != 7 {
+		t.Fatalf("Unexpected frame %v", frames[1])
+	}
+
+	for _, r := range [...][2]varint.Int62{
+		{0xaf, 0xaf},
+		{0xa0, 0xb0},
+		{0x1e, 0x20},
+		{0x31, 0x31},
+	} {
+		if _, err := Frame.RegisterFrameType(r[0], r[1], readAckFrequencyFrame); err != Frame.FrameValueAlreadyInUse {
+			t.Fatal("Expected", Frame.FrameValueAlreadyInUse, "but got", err, "for", r)
+		}
+	}
+
+	if _, err := Frame.RegisterFrameType(0xb1, 0xb0, readAckFrequencyFrame); err != Frame.InvalidFrameValueRange {
+		t.Fatal("Expected", Frame.InvalidFrameValueRange, "but got", err)
+	}
+}
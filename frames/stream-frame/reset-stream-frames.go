@@ -0,0 +1,149 @@
+package StreamFrame
+
+import (
+	"bufio"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	StreamIdentifier "github.com/udan-jayanith/Quick/stream-identifier"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+const (
+	ResetStreamFrameType varint.Int62 = 0x04
+	StopSendingFrameType varint.Int62 = 0x05
+)
+
+/*
+RESET_STREAM Frame {
+  Type (i) = 0x04,
+  Stream ID (i),
+  Application Protocol Error Code (i),
+  Final Size (i),
+}
+*/
+
+// An endpoint uses a RESET_STREAM frame to abruptly terminate the sending part of a stream.
+type ResetStreamFrame struct {
+	StreamID StreamIdentifier.StreamID
+	// Application protocol error code that indicates why the stream is being closed.
+	ApplicationErrorCode varint.Int62
+	// Final size of the stream by the RESET_STREAM sender, in units of bytes.
+	FinalSize varint.Int62
+}
+
+func (f *ResetStreamFrame) FrameValue() varint.Int62 {
+	return ResetStreamFrameType
+}
+
+func (f *ResetStreamFrame) Len() int {
+	return varint.VarintLength(ResetStreamFrameType) + varint.VarintLength(f.StreamID.ToInt62()) +
+		varint.VarintLength(f.ApplicationErrorCode) + varint.VarintLength(f.FinalSize)
+}
+
+// Append appends the encoded ResetStreamFrame to b.
+// If any field is overflowing Append returns b and varint.IntegerOverflow.
+func (f *ResetStreamFrame) Append(b []byte) ([]byte, error) {
+	buf := b
+	for _, v := range [...]varint.Int62{ResetStreamFrameType, f.StreamID.ToInt62(), f.ApplicationErrorCode, f.FinalSize} {
+		encoded, err := varint.Int62ToVarint(v)
+		if err != nil {
+			return b, err
+		}
+		buf = append(buf, encoded...)
+	}
+	return buf, nil
+}
+
+func ReadResetStreamFrame(rd *bufio.Reader) (ResetStreamFrame, QuicErr.Err) {
+	f := ResetStreamFrame{}
+
+	//Decode the frame type.
+	if v, err := varint.ReadVarint62(rd); err != nil || v != ResetStreamFrameType {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	//Decode stream id
+	if v, err := varint.ReadVarint62(rd); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	} else {
+		f.StreamID = StreamIdentifier.NewStreamID(v)
+	}
+
+	//Decode application protocol error code
+	if v, err := varint.ReadVarint62(rd); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	} else {
+		f.ApplicationErrorCode = v
+	}
+
+	//Decode final size. A variable-length integer cannot exceed 2^62-1, so every decoded final size is valid.
+	if v, err := varint.ReadVarint62(rd); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	} else {
+		f.FinalSize = v
+	}
+
+	return f, QuicErr.NO_ERROR
+}
+
+/*
+STOP_SENDING Frame {
+  Type (i) = 0x05,
+  Stream ID (i),
+  Application Protocol Error Code (i),
+}
+*/
+
+// An endpoint uses a STOP_SENDING frame to communicate that incoming data is being discarded on receipt per application request.
+type StopSendingFrame struct {
+	StreamID StreamIdentifier.StreamID
+	// Application-specified reason the sender is ignoring the stream.
+	ApplicationErrorCode varint.Int62
+}
+
+func (f *StopSendingFrame) FrameValue() varint.Int62 {
+	return StopSendingFrameType
+}
+
+func (f *StopSendingFrame) Len() int {
+	return varint.VarintLength(StopSendingFrameType) + varint.VarintLength(f.StreamID.ToInt62()) + varint.VarintLength(f.ApplicationErrorCode)
+}
+
+// Append appends the encoded StopSendingFrame to b.
+// If any field is overflowing Append returns b and varint.IntegerOverflow.
+func (f *StopSendingFrame) Append(b []byte) ([]byte, error) {
+	buf := b
+	for _, v := range [...]varint.Int62{StopSendingFrameType, f.StreamID.ToInt62(), f.ApplicationErrorCode} {
+		encoded, err := varint.Int62ToVarint(v)
+		if err != nil {
+			return b, err
+		}
+		buf = append(buf, encoded...)
+	}
+	return buf, nil
+}
+
+func ReadStopSendingFrame(rd *bufio.Reader) (StopSendingFrame, QuicErr.Err) {
+	f := StopSendingFrame{}
+
+	//Decode the frame type.
+	if v, err := varint.ReadVarint62(rd); err != nil || v != StopSendingFrameType {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	}
+
+	//Decode stream id
+	if v, err := varint.ReadVarint62(rd); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	} else {
+		f.StreamID = StreamIdentifier.NewStreamID(v)
+	}
+
+	//Decode application protocol error code
+	if v, err := varint.ReadVarint62(rd); err != nil {
+		return f, QuicErr.FRAME_ENCODING_ERROR
+	} else {
+		f.ApplicationErrorCode = v
+	}
+
+	return f, QuicErr.NO_ERROR
+}
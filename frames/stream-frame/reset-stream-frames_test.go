@@ -0,0 +1,85 @@
+package StreamFrame_test
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	StreamFrame "github.com/udan-jayanith/Quick/frames/stream-frame"
+	Quick_test "github.com/udan-jayanith/Quick/internal/testing"
+	StreamIdentifier "github.com/udan-jayanith/Quick/stream-identifier"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+func TestResetStreamFrameEncodeAndDecode(t *testing.T) {
+	f := StreamFrame.ResetStreamFrame{
+		StreamID:             StreamIdentifier.NewStreamID(StreamIdentifier.ServerInitiatedBidi),
+		ApplicationErrorCode: 0x1234,
+		FinalSize:            varint.MaxInt62,
+	}
+	f.StreamID.Increment()
+
+	b, err := f.Append(nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != f.Len() {
+		t.Fatal("Expected", f.Len(), "bytes but got", len(b))
+	}
+
+	// Add few extra bytes to check if ReadResetStreamFrame reads more bytes then it should.
+	rd := bufio.NewReader(bytes.NewReader(append(b, make([]byte, 10)...)))
+	newFrame, qerr := StreamFrame.ReadResetStreamFrame(rd)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newFrame != f {
+		t.Log("Expected")
+		t.Log(Quick_test.ToFormattedJson(f))
+		t.Log("but got")
+		t.Log(Quick_test.ToFormattedJson(newFrame))
+		t.FailNow()
+	} else if rd.Buffered() != 10 {
+		t.Fatal("Expected 10 bytes to be left but", rd.Buffered(), "bytes left")
+	}
+
+	f.FinalSize = varint.MaxInt62 + 1
+	if _, err := f.Append(nil); err != varint.IntegerOverflow {
+		t.Fatal("Expected", varint.IntegerOverflow, "but got", err)
+	}
+
+	// Truncated final size.
+	if _, qerr := StreamFrame.ReadResetStreamFrame(bufio.NewReader(bytes.NewReader(b[:len(b)-1]))); qerr != QuicErr.FRAME_ENCODING_ERROR {
+		t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+	}
+}
+
+func TestStopSendingFrameEncodeAndDecode(t *testing.T) {
+	f := StreamFrame.StopSendingFrame{
+		StreamID:             StreamIdentifier.NewStreamID(StreamIdentifier.ClientInitiatedUni),
+		ApplicationErrorCode: 7,
+	}
+
+	b, err := f.Append(nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(b) != f.Len() {
+		t.Fatal("Expected", f.Len(), "bytes but got", len(b))
+	}
+
+	newFrame, qerr := StreamFrame.ReadStopSendingFrame(bufio.NewReader(bytes.NewReader(b)))
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal("Unexpected error", qerr.Error())
+	} else if newFrame != f {
+		t.Log("Expected")
+		t.Log(Quick_test.ToFormattedJson(f))
+		t.Log("but got")
+		t.Log(Quick_test.ToFormattedJson(newFrame))
+		t.FailNow()
+	}
+
+	// A RESET_STREAM frame is not a STOP_SENDING frame.
+	b[0] = byte(StreamFrame.ResetStreamFrameType)
+	if _, qerr := StreamFrame.ReadStopSendingFrame(bufio.NewReader(bytes.NewReader(b))); qerr != QuicErr.FRAME_ENCODING_ERROR {
+		t.Fatal("Expected", QuicErr.FRAME_ENCODING_ERROR.Error(), "but got", qerr.Error())
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
-	"fmt"
 	"io"
 
 	QuicErr "github.com/udan-jayanith/Quick/errors"
@@ -133,8 +132,6 @@ func (sf *StreamFrame) Encode() ([]byte, *bytes.Reader, error) {
 	// StreamFrame type
 	{
 		if !sf.Type.IsValid() {
-			fmt.Printf("%b\n", sf.Type)
-			fmt.Printf("%v\n", sf.Type)
 			return []byte{}, nil, InvalidStreamFrameType
 		}
 
@@ -230,3 +227,44 @@ func ReadStreamFrame(rd *bufio.Reader) (StreamFrame, QuicErr.Err) {
 	sf.StreamData = bytes.NewReader(buf)
 	return sf, QuicErr.NO_ERROR
 }
+
+// FrameValue returns the frame type value of the StreamFrame.
+func (sf *StreamFrame) FrameValue() varint.Int62 {
+	return varint.Int62(sf.Type)
+}
+
+// Len returns the number of bytes Append appends.
+func (sf *StreamFrame) Len() int {
+	n := varint.VarintLength(varint.Int62(sf.Type)) + varint.VarintLength(sf.StreamID.ToInt62())
+	if sf.Type.GetOffset() {
+		n += varint.VarintLength(sf.Offset)
+	}
+	if sf.Type.GetLength() {
+		n += varint.VarintLength(sf.Length)
+	}
+	if sf.StreamData != nil {
+		n += sf.StreamData.Len()
+	}
+	return n
+}
+
+// Append appends the encoded StreamFrame including the unread Stream Data to b.
+// Append does not consume the StreamData.
+func (sf *StreamFrame) Append(b []byte) ([]byte, error) {
+	header, data, err := sf.Encode()
+	if err != nil {
+		return b, err
+	}
+	buf := append(b, header...)
+
+	if data == nil || data.Len() == 0 {
+		return buf, nil
+	}
+
+	start := len(buf)
+	buf = append(buf, make([]byte, data.Len())...)
+	if _, err := data.ReadAt(buf[start:], data.Size()-int64(data.Len())); err != nil {
+		return b, err
+	}
+	return buf, nil
+}
@@ -2,4 +2,9 @@ module github.com/udan-jayanith/Quick
 
 go 1.25.0
 
-require github.com/xyproto/randomstring v1.2.0
+require (
+	github.com/xyproto/randomstring v1.2.0
+	golang.org/x/crypto v0.52.0
+)
+
+require golang.org/x/sys v0.45.0 // indirect
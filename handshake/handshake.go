@@ -0,0 +1,309 @@
+package Handshake
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+
+	CryptoStream "github.com/udan-jayanith/Quick/crypto-stream"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	PacketProtection "github.com/udan-jayanith/Quick/packet-protection"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+var (
+	// OneRTTKeysUnavailable is returned when 1-RTT keys are requested before TLS installs them in both directions.
+	OneRTTKeysUnavailable error = errors.New("1-RTT keys are not available")
+)
+
+// Handshake runs the TLS 1.3 handshake of a connection over CRYPTO frames using crypto/tls.
+// CRYPTO data flows per packet number space and the secrets installed by TLS become packet protection keys.
+//
+// Handshake is not safe for concurrent use.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-4
+type Handshake struct {
+	role                Packet.Role
+	conn                *tls.QUICConn
+	transportParameters []byte
+
+	receiveStreams CryptoStream.CryptoStreams
+	// sendBuffers hold CRYPTO data written by TLS that is not yet sent. sendOffsets are the stream offsets of the first byte of sendBuffers.
+	sendBuffers [3][]byte
+	sendOffsets [3]varint.Int62
+	// unacked holds the sent CRYPTO data that is not acknowledged, keyed by the stream offset.
+	// retransmissions are the offsets of lost CRYPTO data in unacked to be sent again.
+	unacked         [3]map[varint.Int62][]byte
+	retransmissions [3][]varint.Int62
+
+	// Keys indexed by the packet number space. 0-RTT keys are kept apart from the 1-RTT keys.
+	sealKeys, openKeys [3]*PacketProtection.Keys
+	zeroRTTKeys        *PacketProtection.Keys
+
+	peerTransportParameters []byte
+	complete                bool
+}
+
+func newHandshake(role Packet.Role, config *tls.Config, transportParameters []byte) *Handshake {
+	// QUIC requires TLS 1.3 or later.
+	if config == nil {
+		config = &tls.Config{}
+	} else {
+		config = config.Clone()
+	}
+	config.MinVersion = tls.VersionTLS13
+
+	h := &Handshake{
+		role:                role,
+		transportParameters: transportParameters,
+		receiveStreams:      CryptoStream.NewCryptoStreams(CryptoStream.DefaultMaxBufferSize),
+	}
+	for space := range h.unacked {
+		h.unacked[space] = map[varint.Int62][]byte{}
+	}
+	if role == Packet.Client {
+		h.conn = tls.QUICClient(&tls.QUICConfig{TLSConfig: config})
+	} else {
+		h.conn = tls.QUICServer(&tls.QUICConfig{TLSConfig: config})
+	}
+	return h
+}
+
+// NewClient returns the Handshake of a client. A nil config is the zero tls.Config. transportParameters are the encoded transport parameters sent to the server.
+func NewClient(config *tls.Config, transportParameters []byte) *Handshake {
+	return newHandshake(Packet.Client, config, transportParameters)
+}
+
+// NewServer returns the Handshake of a server. A nil config is the zero tls.Config. transportParameters are the encoded transport parameters sent to the client.
+func NewServer(config *tls.Config, transportParameters []byte) *Handshake {
+	return newHandshake(Packet.Server, config, transportParameters)
+}
+
+// Start starts the handshake. The ClientHello of a client is queued in the Initial packet number space.
+func (h *Handshake) Start(ctx context.Context) error {
+	if h.role == Packet.Client {
+		h.conn.SetTransportParameters(h.transportParameters)
+	}
+	if err := h.conn.Start(ctx); err != nil {
+		return cryptoError(err, 0)
+	}
+	return h.handleEvents()
+}
+
+// Close closes the TLS connection.
+func (h *Handshake) Close() error {
+	return h.conn.Close()
+}
+
+// HandleCryptoFrame passes the CRYPTO data received in a packet of the space to TLS.
+// If TLS fails with a alert HandleCryptoFrame returns a QuicErr.TransportError with the CRYPTO_ERROR of the alert.
+func (h *Handshake) HandleCryptoFrame(space Packet.PacketNumberSpace, f *Frame.CryptoFrame) error {
+	if qerr := h.receiveStreams.Get(space).Push(f); qerr != QuicErr.NO_ERROR {
+		return &QuicErr.TransportError{Code: qerr, FrameValue: f.FrameValue()}
+	}
+
+	data := h.receiveStreams.Get(space).Read()
+	if len(data) == 0 {
+		return nil
+	}
+	if err := h.conn.HandleData(encryptionLevel(space), data); err != nil {
+		return cryptoError(err, f.FrameValue())
+	}
+	return h.handleEvents()
+}
+
+// cryptoDataLength returns the length of the CRYPTO data that fits in a CRYPTO frame of maxLen bytes at the offset.
+func cryptoDataLength(offset varint.Int62, maxLen int) int {
+	// Type (i) + Offset (i) + Length (i)
+	return maxLen - 1 - varint.VarintLength(offset) - varint.VarintLength(varint.Int62(maxLen))
+}
+
+// NextCryptoFrame dequeues CRYPTO data of the space that fits in a CRYPTO frame of maxLen bytes.
+// Lost CRYPTO data is sent before new CRYPTO data. The data is kept until OnCryptoFrameAcked, so a frame reported to OnCryptoFrameLost is sent again.
+// If there is no CRYPTO data to send or it doesn't fit NextCryptoFrame returns false.
+func (h *Handshake) NextCryptoFrame(space Packet.PacketNumberSpace, maxLen int) (Frame.CryptoFrame, bool) {
+	for len(h.retransmissions[space]) != 0 {
+		offset := h.retransmissions[space][0]
+		data, ok := h.unacked[space][offset]
+		if !ok {
+			// The data is acknowledged.
+			h.retransmissions[space] = h.retransmissions[space][1:]
+			continue
+		}
+
+		n := min(cryptoDataLength(offset, maxLen), len(data))
+		if n <= 0 {
+			return Frame.CryptoFrame{}, false
+		} else if n < len(data) {
+			// The rest of the data is sent in a later frame.
+			h.unacked[space][offset] = data[:n:n]
+			h.unacked[space][offset+varint.Int62(n)] = data[n:]
+			h.retransmissions[space][0] = offset + varint.Int62(n)
+		} else {
+			h.retransmissions[space] = h.retransmissions[space][1:]
+		}
+		return Frame.CryptoFrame{Offset: offset, CryptoData: data[:n:n]}, true
+	}
+
+	offset := h.sendOffsets[space]
+	n := min(cryptoDataLength(offset, maxLen), len(h.sendBuffers[space]))
+	if n <= 0 {
+		return Frame.CryptoFrame{}, false
+	}
+
+	f := Frame.CryptoFrame{
+		Offset:     offset,
+		CryptoData: h.sendBuffers[space][:n:n],
+	}
+	h.unacked[space][offset] = f.CryptoData
+	h.sendBuffers[space] = h.sendBuffers[space][n:]
+	h.sendOffsets[space] += varint.Int62(n)
+	return f, true
+}
+
+// OnCryptoFrameLost queues the CRYPTO data of a lost CRYPTO frame of the space to be sent again.
+// Data that is already acknowledged is not sent again.
+func (h *Handshake) OnCryptoFrameLost(space Packet.PacketNumberSpace, f *Frame.CryptoFrame) {
+	if data, ok := h.unacked[space][f.Offset]; ok && len(data) == len(f.CryptoData) {
+		h.retransmissions[space] = append(h.retransmissions[space], f.Offset)
+	}
+}
+
+// OnCryptoFrameAcked releases the CRYPTO data of a acknowledged CRYPTO frame of the space.
+func (h *Handshake) OnCryptoFrameAcked(space Packet.PacketNumberSpace, f *Frame.CryptoFrame) {
+	end := f.Offset + varint.Int62(len(f.CryptoData))
+	for offset, data := range h.unacked[space] {
+		// A frame sent again can be split, so every piece inside the acknowledged range is released.
+		if offset >= f.Offset && offset+varint.Int62(len(data)) <= end {
+			delete(h.unacked[space], offset)
+		}
+	}
+}
+
+// HasCryptoData reports whether there is CRYPTO data of the space to send.
+func (h *Handshake) HasCryptoData(space Packet.PacketNumberSpace) bool {
+	for _, offset := range h.retransmissions[space] {
+		if _, ok := h.unacked[space][offset]; ok {
+			return true
+		}
+	}
+	return len(h.sendBuffers[space]) != 0
+}
+
+// SealKeys returns the keys used to protect packets of the space. SealKeys returns nil until TLS installs them.
+// Initial keys are derived from the Destination Connection ID, so TLS doesn't install them.
+func (h *Handshake) SealKeys(space Packet.PacketNumberSpace) *PacketProtection.Keys {
+	return h.sealKeys[space]
+}
+
+// OpenKeys returns the keys used to unprotect packets of the space. OpenKeys returns nil until TLS installs them.
+func (h *Handshake) OpenKeys(space Packet.PacketNumberSpace) *PacketProtection.Keys {
+	return h.openKeys[space]
+}
+
+// ZeroRTTKeys returns the keys of 0-RTT packets. They protect packets of a client and unprotect packets of a server.
+// ZeroRTTKeys returns nil if 0-RTT is not used.
+func (h *Handshake) ZeroRTTKeys() *PacketProtection.Keys {
+	return h.zeroRTTKeys
+}
+
+// OneRTTKeys returns OneRTTKeys of the 1-RTT keys installed by TLS.
+// If TLS hasn't installed the keys in both directions OneRTTKeys returns OneRTTKeysUnavailable.
+func (h *Handshake) OneRTTKeys() (*PacketProtection.OneRTTKeys, error) {
+	seal, open := h.sealKeys[Packet.ApplicationDataSpace], h.openKeys[Packet.ApplicationDataSpace]
+	if seal == nil || open == nil {
+		return nil, OneRTTKeysUnavailable
+	}
+	return PacketProtection.NewOneRTTKeys(seal, open)
+}
+
+// PeerTransportParameters returns the encoded transport parameters received from the peer.
+// PeerTransportParameters returns nil until they are received.
+func (h *Handshake) PeerTransportParameters() []byte {
+	return h.peerTransportParameters
+}
+
+// IsComplete reports whether the TLS handshake is complete.
+func (h *Handshake) IsComplete() bool {
+	return h.complete
+}
+
+// ConnectionState returns the state of the TLS connection, such as the negotiated ALPN protocol and the peer certificates.
+func (h *Handshake) ConnectionState() tls.ConnectionState {
+	return h.conn.ConnectionState()
+}
+
+func (h *Handshake) handleEvents() error {
+	for {
+		e := h.conn.NextEvent()
+		switch e.Kind {
+		case tls.QUICNoEvent:
+			return nil
+		case tls.QUICSetReadSecret, tls.QUICSetWriteSecret:
+			// e.Data is owned by crypto/tls.
+			keys, err := PacketProtection.NewKeys(e.Suite, append([]byte(nil), e.Data...))
+			if err != nil {
+				return &QuicErr.TransportError{Code: QuicErr.INTERNAL_ERROR, Reason: err.Error()}
+			}
+			h.setKeys(e.Kind == tls.QUICSetWriteSecret, e.Level, keys)
+		case tls.QUICWriteData:
+			space := packetNumberSpace(e.Level)
+			h.sendBuffers[space] = append(h.sendBuffers[space], e.Data...)
+		case tls.QUICTransportParameters:
+			h.peerTransportParameters = append([]byte{}, e.Data...)
+		case tls.QUICTransportParametersRequired:
+			h.conn.SetTransportParameters(h.transportParameters)
+		case tls.QUICHandshakeDone:
+			h.complete = true
+		}
+	}
+}
+
+func (h *Handshake) setKeys(write bool, level tls.QUICEncryptionLevel, keys *PacketProtection.Keys) {
+	if level == tls.QUICEncryptionLevelEarly {
+		h.zeroRTTKeys = keys
+		return
+	}
+
+	space := packetNumberSpace(level)
+	if write {
+		h.sealKeys[space] = keys
+	} else {
+		h.openKeys[space] = keys
+	}
+}
+
+func encryptionLevel(space Packet.PacketNumberSpace) tls.QUICEncryptionLevel {
+	switch space {
+	case Packet.InitialSpace:
+		return tls.QUICEncryptionLevelInitial
+	case Packet.HandshakeSpace:
+		return tls.QUICEncryptionLevelHandshake
+	}
+	return tls.QUICEncryptionLevelApplication
+}
+
+// 0-RTT packets can't carry CRYPTO frames, so the early encryption level shares the packet number space with 1-RTT.
+func packetNumberSpace(level tls.QUICEncryptionLevel) Packet.PacketNumberSpace {
+	switch level {
+	case tls.QUICEncryptionLevelInitial:
+		return Packet.InitialSpace
+	case tls.QUICEncryptionLevelHandshake:
+		return Packet.HandshakeSpace
+	}
+	return Packet.ApplicationDataSpace
+}
+
+// cryptoError maps a error returned by crypto/tls to a TransportError.
+// TLS alerts are carried as CRYPTO_ERROR codes and other errors become INTERNAL_ERROR.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-4.8
+func cryptoError(err error, frameValue varint.Int62) error {
+	var alert tls.AlertError
+	if errors.As(err, &alert) {
+		return &QuicErr.TransportError{Code: QuicErr.CryptoError(uint8(alert)), FrameValue: frameValue, Reason: err.Error()}
+	}
+	return &QuicErr.TransportError{Code: QuicErr.INTERNAL_ERROR, FrameValue: frameValue, Reason: err.Error()}
+}
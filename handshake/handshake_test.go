@@ -0,0 +1,234 @@
+package Handshake_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Handshake "github.com/udan-jayanith/Quick/handshake"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+func selfSignedCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: certificate}, pool
+}
+
+// exchange moves CRYPTO frames between the client and the server until neither has CRYPTO data to send.
+func exchange(client, server *Handshake.Handshake) error {
+	for {
+		moved := false
+		for _, endpoints := range [...][2]*Handshake.Handshake{{client, server}, {server, client}} {
+			for space := Packet.InitialSpace; space <= Packet.ApplicationDataSpace; space++ {
+				for {
+					f, ok := endpoints[0].NextCryptoFrame(space, 1200)
+					if !ok {
+						break
+					}
+					moved = true
+					if err := endpoints[1].HandleCryptoFrame(space, &f); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if !moved {
+			return nil
+		}
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	certificate, pool := selfSignedCertificate(t)
+	client := Handshake.NewClient(&tls.Config{RootCAs: pool, ServerName: "localhost", NextProtos: []string{"quick"}}, []byte("client parameters"))
+	server := Handshake.NewServer(&tls.Config{Certificates: []tls.Certificate{certificate}, NextProtos: []string{"quick"}}, []byte("server parameters"))
+	defer client.Close()
+	defer server.Close()
+
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	} else if err := client.Start(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	} else if !client.HasCryptoData(Packet.InitialSpace) {
+		t.Fatal("Expected the ClientHello to be queued in the Initial packet number space")
+	}
+
+	if err := exchange(client, server); err != nil {
+		t.Fatal(err.Error())
+	} else if !client.IsComplete() || !server.IsComplete() {
+		t.Fatal("Expected the handshake to be complete")
+	}
+
+	if string(client.PeerTransportParameters()) != "server parameters" || string(server.PeerTransportParameters()) != "client parameters" {
+		t.Fatal("Unexpected transport parameters", string(client.PeerTransportParameters()), string(server.PeerTransportParameters()))
+	} else if client.ConnectionState().NegotiatedProtocol != "quick" {
+		t.Fatal("Unexpected application protocol", client.ConnectionState().NegotiatedProtocol)
+	}
+
+	// The secrets installed by TLS protect packets of the peer.
+	for _, space := range [...]Packet.PacketNumberSpace{Packet.HandshakeSpace, Packet.ApplicationDataSpace} {
+		for _, endpoints := range [...][2]*Handshake.Handshake{{client, server}, {server, client}} {
+			sealKeys, openKeys := endpoints[0].SealKeys(space), endpoints[1].OpenKeys(space)
+			if sealKeys == nil || openKeys == nil {
+				t.Fatal("Expected keys of the packet number space", space)
+			} else if !bytes.Equal(sealKeys.Secret, openKeys.Secret) {
+				t.Fatal("Expected the keys of the packet number space", space, "to match")
+			}
+		}
+	}
+
+	clientKeys, err := client.OneRTTKeys()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	serverKeys, err := server.OneRTTKeys()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	h := Packet.ShortHeader{}
+	packet, err := h.Append(nil, []byte{0x01})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	packet, err = clientKeys.Protect(append(packet, []byte("payload")...), h.PacketNumberOffset, 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	payload, _, err := serverKeys.Unprotect(packet, h.PacketNumberOffset, 0, time.Now())
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if string(payload) != "payload" {
+		t.Fatal("Unexpected payload", string(payload))
+	}
+}
+
+func TestCryptoFrameLost(t *testing.T) {
+	certificate, pool := selfSignedCertificate(t)
+	client := Handshake.NewClient(&tls.Config{RootCAs: pool, ServerName: "localhost"}, nil)
+	server := Handshake.NewServer(&tls.Config{Certificates: []tls.Certificate{certificate}}, nil)
+	defer client.Close()
+	defer server.Close()
+
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	} else if err := client.Start(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// The ClientHello is lost.
+	var lost []Frame.CryptoFrame
+	for {
+		f, ok := client.NextCryptoFrame(Packet.InitialSpace, 1200)
+		if !ok {
+			break
+		}
+		lost = append(lost, f)
+	}
+	if client.HasCryptoData(Packet.InitialSpace) {
+		t.Fatal("Expected no CRYPTO data to send")
+	}
+	for i := range lost {
+		client.OnCryptoFrameLost(Packet.InitialSpace, &lost[i])
+	}
+	if !client.HasCryptoData(Packet.InitialSpace) {
+		t.Fatal("Expected the lost CRYPTO data to be sent again")
+	}
+
+	// Lost CRYPTO data is sent again in smaller frames. Acknowledged CRYPTO data is not sent again.
+	f, ok := client.NextCryptoFrame(Packet.InitialSpace, 100)
+	if !ok || f.Offset != 0 || len(f.CryptoData) >= len(lost[0].CryptoData) || !bytes.Equal(f.CryptoData, lost[0].CryptoData[:len(f.CryptoData)]) {
+		t.Fatal("Expected the start of the ClientHello but got", f)
+	}
+	if err := server.HandleCryptoFrame(Packet.InitialSpace, &f); err != nil {
+		t.Fatal(err.Error())
+	}
+	client.OnCryptoFrameAcked(Packet.InitialSpace, &f)
+	client.OnCryptoFrameLost(Packet.InitialSpace, &f)
+	if next, ok := client.NextCryptoFrame(Packet.InitialSpace, 1200); !ok || next.Offset != varint.Int62(len(f.CryptoData)) {
+		t.Fatal("Expected the rest of the ClientHello but got", next)
+	} else if err := server.HandleCryptoFrame(Packet.InitialSpace, &next); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := exchange(client, server); err != nil {
+		t.Fatal(err.Error())
+	} else if !client.IsComplete() || !server.IsComplete() {
+		t.Fatal("Expected the handshake to be complete")
+	}
+}
+
+func TestHandshakeAlert(t *testing.T) {
+	certificate, _ := selfSignedCertificate(t)
+	// The client doesn't trust the certificate of the server.
+	client := Handshake.NewClient(&tls.Config{RootCAs: x509.NewCertPool(), ServerName: "localhost", NextProtos: []string{"quick"}}, nil)
+	server := Handshake.NewServer(&tls.Config{Certificates: []tls.Certificate{certificate}, NextProtos: []string{"quick"}}, nil)
+	defer client.Close()
+	defer server.Close()
+
+	if _, err := client.OneRTTKeys(); err != Handshake.OneRTTKeysUnavailable {
+		t.Fatal("Expected", Handshake.OneRTTKeysUnavailable, "but got", err)
+	}
+
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	} else if err := client.Start(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err := exchange(client, server)
+	var transportError *QuicErr.TransportError
+	if !errors.As(err, &transportError) {
+		t.Fatal("Expected a QuicErr.TransportError but got", err)
+	} else if !transportError.Code.IsCryptoError() {
+		t.Fatalf("Expected a CRYPTO_ERROR but got 0x%x", uint64(transportError.Code))
+	} else if transportError.FrameValue != 0x06 {
+		t.Fatal("Expected the error to be triggered by a CRYPTO frame but got", transportError.FrameValue)
+	}
+}
+
+func TestNilConfig(t *testing.T) {
+	client := Handshake.NewClient(nil, nil)
+	defer client.Close()
+	server := Handshake.NewServer(nil, nil)
+	defer server.Close()
+
+	// The zero tls.Config has no ServerName, so the client cannot start.
+	if err := client.Start(context.Background()); err == nil {
+		t.Fatal("Expected a error but got nil")
+	}
+}
@@ -1,8 +1,10 @@
 package Testing
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/udan-jayanith/Quick/varint"
 )
@@ -22,3 +24,12 @@ func Int62ToVarint(v varint.Int62) []byte {
 	}
 	return b
 }
+
+// Unhex decodes a hex string ignoring white spaces.
+func Unhex(s string) []byte {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		panic(fmt.Sprintf("Unhex failed\n%s\nFrom internal/Testing pkg.", err.Error()))
+	}
+	return b
+}
@@ -0,0 +1,126 @@
+package PacketProtection
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"hash"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// Every AEAD used by QUIC version 1 has a 12 byte long nonce.
+	NonceLength int = 12
+)
+
+// Usage limits of the AEADs.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-6.6
+const (
+	aesGCMConfidentialityLimit uint64 = 1 << 23
+	aesGCMIntegrityLimit       uint64 = 1 << 52
+	// The confidentiality limit of AEAD_CHACHA20_POLY1305 is greater than the number of possible packets (2^62).
+	chaCha20Poly1305ConfidentialityLimit uint64 = 1 << 62
+	chaCha20Poly1305IntegrityLimit       uint64 = 1 << 36
+)
+
+type cipherSuite struct {
+	hash      func() hash.Hash
+	keyLength int
+	newAEAD   func(key []byte) (cipher.AEAD, error)
+
+	confidentialityLimit uint64
+	integrityLimit       uint64
+}
+
+var (
+	// Packets are protected with the AEAD of the cipher suite negotiated by TLS.
+	//
+	// https://datatracker.ietf.org/doc/html/rfc9001#section-5.3
+	cipherSuites = map[uint16]cipherSuite{
+		tls.TLS_AES_128_GCM_SHA256: {
+			hash: sha256.New, keyLength: 16, newAEAD: newAESGCM,
+			confidentialityLimit: aesGCMConfidentialityLimit, integrityLimit: aesGCMIntegrityLimit,
+		},
+		tls.TLS_AES_256_GCM_SHA384: {
+			hash: sha512.New384, keyLength: 32, newAEAD: newAESGCM,
+			confidentialityLimit: aesGCMConfidentialityLimit, integrityLimit: aesGCMIntegrityLimit,
+		},
+		tls.TLS_CHACHA20_POLY1305_SHA256: {
+			hash: sha256.New, keyLength: chacha20poly1305.KeySize, newAEAD: chacha20poly1305.New,
+			confidentialityLimit: chaCha20Poly1305ConfidentialityLimit, integrityLimit: chaCha20Poly1305IntegrityLimit,
+		},
+	}
+)
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Overhead returns the number of bytes packet protection adds to the payload.
+func (k *Keys) Overhead() int {
+	return k.aead.Overhead()
+}
+
+// nonce is formed by combining the IV with the packet number.
+// The 62 bits of the reconstructed QUIC packet number in network byte order are left-padded with zeros to the size of the IV and XORed with the IV.
+func (k *Keys) nonce(packetNumber Packet.PacketNumber) []byte {
+	nonce := make([]byte, NonceLength)
+	copy(nonce, k.iv)
+	for i := range 8 {
+		nonce[NonceLength-1-i] ^= byte(packetNumber >> (8 * i))
+	}
+	return nonce
+}
+
+// Seal encrypts the payload of the packet in place and returns the packet with the authentication tag appended.
+// packet[:payloadOffset] is the unprotected header including the Packet Number, which is the associated data of the AEAD.
+// If the capacity of packet has room for Overhead bytes the packet is not reallocated.
+//
+// Seal doesn't apply header protection.
+// If ConfidentialityLimit packets are already sealed with the keys Seal returns QuicErr.AEAD_LIMIT_REACHED and the keys must not be used.
+func (k *Keys) Seal(packet []byte, payloadOffset int, packetNumber Packet.PacketNumber) ([]byte, error) {
+	if k.sealedPackets >= k.ConfidentialityLimit {
+		return packet, QuicErr.AEAD_LIMIT_REACHED
+	}
+	k.sealedPackets++
+
+	header := packet[:payloadOffset]
+	return k.aead.Seal(header, k.nonce(packetNumber), packet[payloadOffset:], header), nil
+}
+
+// Open decrypts the payload of the packet in place and returns the plaintext payload.
+// packet[:payloadOffset] is the header including the Packet Number with header protection removed.
+//
+// If the payload cannot be decrypted Open returns DecryptionFailed and the packet must be discarded.
+// If more than IntegrityLimit packets fail authentication Open returns QuicErr.AEAD_LIMIT_REACHED and the connection must be closed.
+func (k *Keys) Open(packet []byte, payloadOffset int, packetNumber Packet.PacketNumber) ([]byte, error) {
+	payload, err := k.aead.Open(packet[payloadOffset:payloadOffset], k.nonce(packetNumber), packet[payloadOffset:], packet[:payloadOffset])
+	if err != nil {
+		k.failedOpens++
+		if k.failedOpens > k.IntegrityLimit {
+			return nil, QuicErr.AEAD_LIMIT_REACHED
+		}
+		return nil, DecryptionFailed
+	}
+	return payload, nil
+}
+
+// SealedPackets returns the number of packets sealed with the keys.
+func (k *Keys) SealedPackets() uint64 {
+	return k.sealedPackets
+}
+
+// FailedOpens returns the number of packets that failed authentication with the keys.
+func (k *Keys) FailedOpens() uint64 {
+	return k.failedOpens
+}
@@ -0,0 +1,125 @@
+package PacketProtection_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	PacketProtection "github.com/udan-jayanith/Quick/packet-protection"
+)
+
+// https://datatracker.ietf.org/doc/html/rfc9001#appendix-A.5
+func TestChaCha20ShortHeaderTestVector(t *testing.T) {
+	keys, err := PacketProtection.NewKeys(tls.TLS_CHACHA20_POLY1305_SHA256, Testing.Unhex("9ac312a7f877468ebe69422748ad00a15443f18203a07d6060f688f30f21632b"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	var packetNumber Packet.PacketNumber = 654360564
+	h := Packet.ShortHeader{DestinationConnectionID: ConnectionIdentifier.ConnectionID{}}
+	packet, err := h.Append(make([]byte, 0, 64), Testing.Unhex("00bff4"))
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if expected := Testing.Unhex("4200bff4"); !bytes.Equal(packet, expected) {
+		t.Fatalf("Test failed.\nExpected header %x\nGot %x", expected, packet)
+	}
+
+	packet = append(packet, 0x01)
+	protected, err := keys.Protect(packet, h.PacketNumberOffset, packetNumber)
+	expected := Testing.Unhex("4cfe4189655e5cd55c41f69080575d7999c25a5bfb")
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if !bytes.Equal(protected, expected) {
+		t.Fatalf("Test failed.\nExpected packet %x\nGot %x", expected, protected)
+	} else if &protected[0] != &packet[0] {
+		t.Fatal("Expected the packet to be protected in place")
+	}
+
+	received, err := Packet.NewShortHeaderParser(Packet.FixedConnectionIDLength(0)).Parse(protected)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	payload, pn, err := keys.Unprotect(protected, received.PacketNumberOffset, packetNumber-1)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if pn != packetNumber || !bytes.Equal(payload, []byte{0x01}) {
+		t.Fatalf("Test failed.\nExpected packet number %v and payload 01\nGot %v and %x", packetNumber, pn, payload)
+	}
+}
+
+func TestSealAndOpen(t *testing.T) {
+	for _, suite := range [...]uint16{tls.TLS_AES_128_GCM_SHA256, tls.TLS_AES_256_GCM_SHA384, tls.TLS_CHACHA20_POLY1305_SHA256} {
+		keys, err := PacketProtection.NewKeys(suite, bytes.Repeat([]byte{0x01}, 48))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		header := Testing.Unhex("4101020304")
+		payload := []byte("payload of the packet")
+		packet := append(append(make([]byte, 0, 64), header...), payload...)
+		sealed, err := keys.Seal(packet, len(header), 3)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if len(sealed) != len(header)+len(payload)+keys.Overhead() {
+			t.Fatalf("Test %v failed.\nExpected %v bytes long packet\nGot %v", suite, len(header)+len(payload)+keys.Overhead(), len(sealed))
+		} else if !bytes.Equal(sealed[:len(header)], header) {
+			t.Fatalf("Test %v failed.\nExpected the header to be unchanged", suite)
+		}
+
+		opened, err := keys.Open(append([]byte{}, sealed...), len(header), 3)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if !bytes.Equal(opened, payload) {
+			t.Fatalf("Test %v failed.\nExpected payload %s\nGot %s", suite, payload, opened)
+		}
+
+		// The header is authenticated as the associated data and the packet number is part of the nonce.
+		tampered := append([]byte{}, sealed...)
+		tampered[1] ^= 0xff
+		if _, err := keys.Open(tampered, len(header), 3); err != PacketProtection.DecryptionFailed {
+			t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+		} else if _, err := keys.Open(append([]byte{}, sealed...), len(header), 4); err != PacketProtection.DecryptionFailed {
+			t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+		}
+	}
+}
+
+func TestKeysUsageLimits(t *testing.T) {
+	keys, err := PacketProtection.NewKeys(tls.TLS_AES_128_GCM_SHA256, clientSecret)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if keys.ConfidentialityLimit != 1<<23 || keys.IntegrityLimit != 1<<52 {
+		t.Fatal("Unexpected usage limits of AEAD_AES_128_GCM", keys.ConfidentialityLimit, keys.IntegrityLimit)
+	}
+	if chaCha20Keys, err := PacketProtection.NewKeys(tls.TLS_CHACHA20_POLY1305_SHA256, clientSecret); err != nil {
+		t.Fatal(err.Error())
+	} else if chaCha20Keys.IntegrityLimit != 1<<36 {
+		t.Fatal("Unexpected integrity limit of AEAD_CHACHA20_POLY1305", chaCha20Keys.IntegrityLimit)
+	}
+
+	keys.ConfidentialityLimit = 2
+	keys.IntegrityLimit = 1
+	for i := range 3 {
+		_, err := keys.Seal(oneRTTPacket(t, 1), 2, 1)
+		if i < 2 && err != nil {
+			t.Fatal(err.Error())
+		} else if i == 2 && err != QuicErr.AEAD_LIMIT_REACHED {
+			t.Fatal("Expected", QuicErr.AEAD_LIMIT_REACHED, "but got", err)
+		}
+	}
+	if keys.SealedPackets() != 2 {
+		t.Fatal("Expected 2 sealed packets but got", keys.SealedPackets())
+	}
+
+	if _, err := keys.Open(oneRTTPacket(t, 1), 2, 1); err != PacketProtection.DecryptionFailed {
+		t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+	} else if _, err := keys.Open(oneRTTPacket(t, 1), 2, 1); err != QuicErr.AEAD_LIMIT_REACHED {
+		t.Fatal("Expected", QuicErr.AEAD_LIMIT_REACHED, "but got", err)
+	} else if keys.FailedOpens() != 2 {
+		t.Fatal("Expected 2 failed opens but got", keys.FailedOpens())
+	}
+}
@@ -0,0 +1,136 @@
+package PacketProtection
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/tls"
+	"encoding/binary"
+
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"golang.org/x/crypto/chacha20"
+)
+
+// HeaderProtector computes the header protection mask from a sample of the protected packet.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5.4
+type HeaderProtector interface {
+	Mask(sample []byte) [5]byte
+}
+
+// NewHeaderProtector returns the HeaderProtector of the cipher suite for the header protection key.
+// AES based cipher suites use AES-ECB and TLS_CHACHA20_POLY1305_SHA256 uses ChaCha20.
+// If the cipher suite is not supported NewHeaderProtector returns UnsupportedCipherSuite.
+func NewHeaderProtector(suite uint16, key []byte) (HeaderProtector, error) {
+	switch suite {
+	case tls.TLS_AES_128_GCM_SHA256, tls.TLS_AES_256_GCM_SHA384:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return &aesHeaderProtector{block: block}, nil
+	case tls.TLS_CHACHA20_POLY1305_SHA256:
+		if len(key) != chacha20.KeySize {
+			return nil, InvalidHeaderProtectionKey
+		}
+		return &chaCha20HeaderProtector{key: [chacha20.KeySize]byte(key)}, nil
+	}
+	return nil, UnsupportedCipherSuite
+}
+
+type aesHeaderProtector struct {
+	block cipher.Block
+}
+
+// mask = AES-ECB(hp_key, sample)
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5.4.3
+func (hp *aesHeaderProtector) Mask(sample []byte) [5]byte {
+	var b [aes.BlockSize]byte
+	hp.block.Encrypt(b[:], sample)
+	return [5]byte(b[:5])
+}
+
+type chaCha20HeaderProtector struct {
+	key [chacha20.KeySize]byte
+}
+
+// counter = sample[0..3]
+// nonce = sample[4..15]
+// mask = ChaCha20(hp_key, counter, nonce, {0,0,0,0,0})
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5.4.4
+func (hp *chaCha20HeaderProtector) Mask(sample []byte) [5]byte {
+	var mask [5]byte
+	c, err := chacha20.NewUnauthenticatedCipher(hp.key[:], sample[4:16])
+	if err != nil {
+		// The key and the nonce are always of the right size.
+		panic(err)
+	}
+	c.SetCounter(binary.LittleEndian.Uint32(sample[:4]))
+	c.XORKeyStream(mask[:], mask[:])
+	return mask
+}
+
+// firstByteMask returns the bits of the first byte protected by header protection.
+func firstByteMask(firstByte byte) byte {
+	if Packet.IsLongHeader(firstByte) {
+		return 0b_1111
+	}
+	return 0b_1_1111
+}
+
+// sample returns the sample of the packet. The sample starts 4 bytes after the start of the Packet Number field.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5.4.2
+func sample(packet []byte, packetNumberOffset int) ([]byte, bool) {
+	sampleOffset := packetNumberOffset + 4
+	if len(packet) < sampleOffset+SampleLength {
+		return nil, false
+	}
+	return packet[sampleOffset : sampleOffset+SampleLength], true
+}
+
+// ProtectHeader applies header protection to the packet in place.
+// The payload of the packet must be protected and the first byte must be unprotected.
+// If the packet is too short to be sampled ProtectHeader returns PacketTooShort.
+func ProtectHeader(hp HeaderProtector, packet []byte, packetNumberOffset int) error {
+	s, ok := sample(packet, packetNumberOffset)
+	if !ok {
+		return PacketTooShort
+	}
+	mask := hp.Mask(s)
+
+	// The Packet Number Length is read before the first byte is masked.
+	packetNumberLength := int(packet[0]&0b_11) + 1
+	packet[0] ^= mask[0] & firstByteMask(packet[0])
+	for i := range packetNumberLength {
+		packet[packetNumberOffset+i] ^= mask[1+i]
+	}
+	return nil
+}
+
+// UnprotectHeader removes header protection from the packet in place and decodes the packet number with DecodePacketNumber.
+// The length of the Packet Number field is read from the unmasked first byte.
+// largestPacketNumber is the largest packet number successfully processed in the packet number space.
+// UnprotectHeader returns the packet number and the length of the Packet Number field.
+//
+// If the packet is too short to be sampled UnprotectHeader returns DecryptionFailed and the packet must be discarded.
+func UnprotectHeader(hp HeaderProtector, packet []byte, packetNumberOffset int, largestPacketNumber Packet.PacketNumber) (Packet.PacketNumber, int, error) {
+	s, ok := sample(packet, packetNumberOffset)
+	if !ok {
+		return 0, 0, DecryptionFailed
+	}
+	mask := hp.Mask(s)
+
+	packet[0] ^= mask[0] & firstByteMask(packet[0])
+	packetNumberLength := int(packet[0]&0b_11) + 1
+	for i := range packetNumberLength {
+		packet[packetNumberOffset+i] ^= mask[1+i]
+	}
+
+	packetNumber, err := Packet.DecodePacketNumber(packet[packetNumberOffset:packetNumberOffset+packetNumberLength], largestPacketNumber)
+	if err != nil {
+		return 0, 0, DecryptionFailed
+	}
+	return packetNumber, packetNumberLength, nil
+}
@@ -0,0 +1,102 @@
+package PacketProtection_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"testing"
+
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	PacketProtection "github.com/udan-jayanith/Quick/packet-protection"
+)
+
+var (
+	// https://datatracker.ietf.org/doc/html/rfc9001#appendix-A
+	headerProtectionTestcases = [...]struct {
+		Name                string
+		Suite               uint16
+		Key                 []byte
+		Sample              []byte
+		ExpectedMask        []byte
+		Header              []byte
+		PacketNumber        Packet.PacketNumber
+		LargestPacketNumber Packet.PacketNumber
+	}{
+		{
+			Name:                "AES",
+			Suite:               tls.TLS_AES_128_GCM_SHA256,
+			Key:                 Testing.Unhex("9f50449e04a0e810283a1e9933adedd2"),
+			Sample:              Testing.Unhex("d1b1c98dd7689fb8ec11d242b123dc9b"),
+			ExpectedMask:        Testing.Unhex("437b9aec36"),
+			Header:              Testing.Unhex("c300000001088394c8f03e5157080000449e00000002"),
+			PacketNumber:        2,
+			LargestPacketNumber: 1,
+		},
+		{
+			Name:                "ChaCha20",
+			Suite:               tls.TLS_CHACHA20_POLY1305_SHA256,
+			Key:                 Testing.Unhex("25a282b9e82f06f21f488917a4fc8f1b73573685608597d0efcb076b0ab7a7a4"),
+			Sample:              Testing.Unhex("5e5cd55c41f69080575d7999c25a5bfb"),
+			ExpectedMask:        Testing.Unhex("aefefe7d03"),
+			Header:              Testing.Unhex("4200bff4"),
+			PacketNumber:        654360564,
+			LargestPacketNumber: 654360563,
+		},
+	}
+)
+
+func TestHeaderProtection(t *testing.T) {
+	for _, testcase := range headerProtectionTestcases {
+		hp, err := PacketProtection.NewHeaderProtector(testcase.Suite, testcase.Key)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		mask := hp.Mask(testcase.Sample)
+		if !bytes.Equal(mask[:], testcase.ExpectedMask) {
+			t.Fatalf("Test %v failed.\nExpected mask %x\nGot %x", testcase.Name, testcase.ExpectedMask, mask)
+		}
+
+		packetNumberLength := int(testcase.Header[0]&0b_11) + 1
+		packetNumberOffset := len(testcase.Header) - packetNumberLength
+		// The sample starts 4 bytes after the start of the Packet Number field.
+		packet := append([]byte{}, testcase.Header...)
+		packet = append(packet, make([]byte, 4-packetNumberLength)...)
+		packet = append(packet, testcase.Sample...)
+
+		if err := PacketProtection.ProtectHeader(hp, packet, packetNumberOffset); err != nil {
+			t.Fatal(err.Error())
+		} else if bytes.Equal(packet[:len(testcase.Header)], testcase.Header) {
+			t.Fatalf("Test %v failed.\nExpected the header to be protected", testcase.Name)
+		}
+
+		packetNumber, length, err := PacketProtection.UnprotectHeader(hp, packet, packetNumberOffset, testcase.LargestPacketNumber)
+		if err != nil {
+			t.Fatal(err.Error())
+		} else if packetNumber != testcase.PacketNumber || length != packetNumberLength {
+			t.Fatalf("Test %v failed.\nExpected packet number %v of %v bytes\nGot %v of %v bytes", testcase.Name, testcase.PacketNumber, packetNumberLength, packetNumber, length)
+		} else if !bytes.Equal(packet[:len(testcase.Header)], testcase.Header) {
+			t.Fatalf("Test %v failed.\nExpected header %x\nGot %x", testcase.Name, testcase.Header, packet[:len(testcase.Header)])
+		}
+	}
+}
+
+func TestHeaderProtectionErrors(t *testing.T) {
+	if _, err := PacketProtection.NewHeaderProtector(tls.TLS_CHACHA20_POLY1305_SHA256, make([]byte, 16)); err != PacketProtection.InvalidHeaderProtectionKey {
+		t.Fatal("Expected", PacketProtection.InvalidHeaderProtectionKey, "but got", err)
+	} else if _, err := PacketProtection.NewHeaderProtector(0x1399, make([]byte, 16)); err != PacketProtection.UnsupportedCipherSuite {
+		t.Fatal("Expected", PacketProtection.UnsupportedCipherSuite, "but got", err)
+	}
+
+	hp, err := PacketProtection.NewHeaderProtector(tls.TLS_AES_128_GCM_SHA256, make([]byte, 16))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	packet := make([]byte, 1+4+PacketProtection.SampleLength-1)
+	packet[0] = 0x40
+	if err := PacketProtection.ProtectHeader(hp, packet, 1); err != PacketProtection.PacketTooShort {
+		t.Fatal("Expected", PacketProtection.PacketTooShort, "but got", err)
+	} else if _, _, err := PacketProtection.UnprotectHeader(hp, packet, 1, 0); err != PacketProtection.DecryptionFailed {
+		t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+	}
+}
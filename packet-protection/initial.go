@@ -0,0 +1,51 @@
+package PacketProtection
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"crypto/tls"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Packet "github.com/udan-jayanith/Quick/packet"
+)
+
+var (
+	// initial_salt of QUIC version 1.
+	InitialSaltV1 = []byte{0x38, 0x76, 0x2c, 0xf7, 0xf5, 0x59, 0x34, 0xb3, 0x4d, 0x17, 0x9a, 0xe6, 0xa4, 0xc8, 0x0c, 0xad, 0xcc, 0xbb, 0x7f, 0x0a}
+)
+
+// InitialSecrets derives the client and server Initial secrets from the Destination Connection ID of the first Initial packet sent by the client.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5.2
+func InitialSecrets(originalDestinationConnectionID ConnectionIdentifier.ConnectionID) (clientSecret, serverSecret []byte, err error) {
+	initialSecret, err := hkdf.Extract(sha256.New, originalDestinationConnectionID, InitialSaltV1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientSecret, err = hkdfExpandLabel(sha256.New, initialSecret, "client in", sha256.Size)
+	if err != nil {
+		return nil, nil, err
+	}
+	serverSecret, err = hkdfExpandLabel(sha256.New, initialSecret, "server in", sha256.Size)
+	return clientSecret, serverSecret, err
+}
+
+// NewInitialKeys returns the keys a endpoint of the role uses to protect and unprotect Initial packets.
+// Initial packets use AEAD_AES_128_GCM.
+func NewInitialKeys(originalDestinationConnectionID ConnectionIdentifier.ConnectionID, role Packet.Role) (sealKeys, openKeys *Keys, err error) {
+	clientSecret, serverSecret, err := InitialSecrets(originalDestinationConnectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if role == Packet.Server {
+		clientSecret, serverSecret = serverSecret, clientSecret
+	}
+
+	sealKeys, err = NewKeys(tls.TLS_AES_128_GCM_SHA256, clientSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	openKeys, err = NewKeys(tls.TLS_AES_128_GCM_SHA256, serverSecret)
+	return sealKeys, openKeys, err
+}
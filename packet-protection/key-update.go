@@ -0,0 +1,254 @@
+package PacketProtection
+
+import (
+	"errors"
+	"time"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Packet "github.com/udan-jayanith/Quick/packet"
+)
+
+const (
+	// DefaultKeyUpdateInterval is the number of packets sealed with the same keys after which a key update is initiated.
+	DefaultKeyUpdateInterval uint64 = 1 << 20
+	// The PTO used before the first RTT sample is 3 times the initial RTT of 333 milliseconds.
+	defaultPTO time.Duration = 999 * time.Millisecond
+)
+
+var (
+	// KeyUpdateNotAllowed is returned when a key update is initiated before the handshake is confirmed
+	// or before a packet sent with the current keys is acknowledged.
+	KeyUpdateNotAllowed error = errors.New("Key update is not allowed")
+)
+
+// Next derives the keys of the next key phase.
+// The header protection key is not updated and the usage limits are inherited.
+//
+// secret_<n+1> = HKDF-Expand-Label(secret_<n>, "quic ku", "", Hash.length)
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-6.1
+func (k *Keys) Next() (*Keys, error) {
+	cs := cipherSuites[k.Suite]
+	secret, err := hkdfExpandLabel(cs.hash, k.Secret, "quic ku", cs.hash().Size())
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := NewKeys(k.Suite, secret)
+	if err != nil {
+		return nil, err
+	}
+	next.hp = k.hp
+	next.ConfidentialityLimit = k.ConfidentialityLimit
+	next.IntegrityLimit = k.IntegrityLimit
+	return next, nil
+}
+
+// OneRTTKeys protects and unprotects 1-RTT packets and performs key updates.
+// The Key Phase bit of 1-RTT packets identifies the keys used to protect the packet.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-6
+type OneRTTKeys struct {
+	// KeyUpdateInterval is the number of packets sealed with the same keys after which a key update is initiated.
+	// If KeyUpdateInterval is 0 key updates are not scheduled.
+	KeyUpdateInterval uint64
+	// PTO is the current probe timeout of the connection. Previous keys are discarded 3 PTOs after a packet is received with the new keys.
+	PTO time.Duration
+
+	keyPhase bool
+	seal     *Keys
+	open     *Keys
+	nextOpen *Keys
+
+	previousOpen *Keys
+	// previousOpenExpiry is zero until a packet is received with the current keys.
+	previousOpenExpiry time.Time
+
+	handshakeConfirmed bool
+	sealedPackets      uint64
+	// failedOpens is the number of packets that failed authentication across all keys of the connection.
+	failedOpens uint64
+
+	sentWithCurrentKeys       bool
+	firstSentPacketNumber     Packet.PacketNumber
+	currentKeysAcknowledged   bool
+	receivedWithCurrentKeys   bool
+	firstReceivedPacketNumber Packet.PacketNumber
+}
+
+// NewOneRTTKeys returns OneRTTKeys of the key phase 0 using the 1-RTT keys derived from the handshake.
+func NewOneRTTKeys(seal, open *Keys) (*OneRTTKeys, error) {
+	nextOpen, err := open.Next()
+	if err != nil {
+		return nil, err
+	}
+	return &OneRTTKeys{
+		KeyUpdateInterval: DefaultKeyUpdateInterval,
+		PTO:               defaultPTO,
+		seal:              seal,
+		open:              open,
+		nextOpen:          nextOpen,
+	}, nil
+}
+
+// KeyPhase returns the current key phase.
+func (k *OneRTTKeys) KeyPhase() bool {
+	return k.keyPhase
+}
+
+// ConfirmHandshake must be called when the handshake is confirmed. Key updates cannot be initiated before it.
+func (k *OneRTTKeys) ConfirmHandshake() {
+	k.handshakeConfirmed = true
+}
+
+// OnPacketAcknowledged must be called for every acknowledged 1-RTT packet.
+// A subsequent key update cannot be initiated until a packet sent with the current keys is acknowledged.
+func (k *OneRTTKeys) OnPacketAcknowledged(packetNumber Packet.PacketNumber) {
+	if k.sentWithCurrentKeys && packetNumber >= k.firstSentPacketNumber {
+		k.currentKeysAcknowledged = true
+	}
+}
+
+// CanInitiateKeyUpdate reports whether a key update can be initiated.
+func (k *OneRTTKeys) CanInitiateKeyUpdate() bool {
+	return k.handshakeConfirmed && k.currentKeysAcknowledged
+}
+
+// InitiateKeyUpdate updates the keys and flips the key phase.
+// If a key update is not allowed InitiateKeyUpdate returns KeyUpdateNotAllowed.
+func (k *OneRTTKeys) InitiateKeyUpdate() error {
+	if !k.CanInitiateKeyUpdate() {
+		return KeyUpdateNotAllowed
+	}
+	return k.update()
+}
+
+func (k *OneRTTKeys) update() error {
+	seal, err := k.seal.Next()
+	if err != nil {
+		return err
+	}
+	nextOpen, err := k.nextOpen.Next()
+	if err != nil {
+		return err
+	}
+
+	k.keyPhase = !k.keyPhase
+	k.seal = seal
+	k.previousOpen, k.open, k.nextOpen = k.open, k.nextOpen, nextOpen
+	k.previousOpenExpiry = time.Time{}
+
+	k.sealedPackets = 0
+	k.sentWithCurrentKeys = false
+	k.currentKeysAcknowledged = false
+	k.receivedWithCurrentKeys = false
+	return nil
+}
+
+// keyUpdateDue reports whether a scheduled key update is due or the current keys are approaching the confidentiality limit.
+func (k *OneRTTKeys) keyUpdateDue() bool {
+	if k.KeyUpdateInterval != 0 && k.sealedPackets >= k.KeyUpdateInterval {
+		return true
+	}
+	// Leave room for the packets sent until a packet sent with the current keys is acknowledged.
+	limit := k.seal.ConfidentialityLimit
+	return k.seal.SealedPackets() >= limit-limit/8
+}
+
+// Protect protects the 1-RTT packet in place with the current keys and sets the Key Phase bit.
+// If a scheduled key update is due and allowed Protect initiates it before protecting the packet.
+// A key update is also initiated before the confidentiality limit of the keys is reached.
+// If the confidentiality limit is reached before a key update is allowed Protect returns QuicErr.AEAD_LIMIT_REACHED.
+func (k *OneRTTKeys) Protect(packet []byte, packetNumberOffset int, packetNumber Packet.PacketNumber) ([]byte, error) {
+	if k.keyUpdateDue() && k.CanInitiateKeyUpdate() {
+		if err := k.update(); err != nil {
+			return packet, err
+		}
+	}
+
+	packet[0] = Packet.SetKeyPhase(packet[0], k.keyPhase)
+	packet, err := k.seal.Protect(packet, packetNumberOffset, packetNumber)
+	if err != nil {
+		return packet, err
+	}
+
+	k.sealedPackets++
+	if !k.sentWithCurrentKeys {
+		k.sentWithCurrentKeys = true
+		k.firstSentPacketNumber = packetNumber
+	}
+	return packet, nil
+}
+
+// Unprotect unprotects the 1-RTT packet in place using the keys of its key phase.
+// A packet of the next key phase that is successfully unprotected updates the keys.
+// Previous keys are kept for reordered packets until 3 PTOs after a packet is received with the current keys.
+//
+// If the packet cannot be unprotected Unprotect returns DecryptionFailed and the packet must be discarded.
+// If the peer updates the keys again before the current keys are used in both directions Unprotect returns QuicErr.KEY_UPDATE_ERROR.
+// If the number of packets that failed authentication across all keys exceeds the integrity limit Unprotect returns QuicErr.AEAD_LIMIT_REACHED.
+func (k *OneRTTKeys) Unprotect(packet []byte, packetNumberOffset int, largestPacketNumber Packet.PacketNumber, now time.Time) ([]byte, Packet.PacketNumber, error) {
+	if k.previousOpen != nil && !k.previousOpenExpiry.IsZero() && !now.Before(k.previousOpenExpiry) {
+		k.previousOpen = nil
+	}
+
+	packetNumber, packetNumberLength, err := UnprotectHeader(k.open.hp, packet, packetNumberOffset, largestPacketNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+	payloadOffset := packetNumberOffset + packetNumberLength
+
+	if Packet.KeyPhase(packet[0]) == k.keyPhase {
+		payload, err := k.openWith(k.open, packet, payloadOffset, packetNumber)
+		if err != nil {
+			return nil, packetNumber, err
+		}
+		k.onReceived(packetNumber, now)
+		return payload, packetNumber, nil
+	}
+
+	// Packets of the previous key phase are older than every packet received with the current keys.
+	if k.previousOpen != nil && (!k.receivedWithCurrentKeys || packetNumber < k.firstReceivedPacketNumber) {
+		payload, err := k.openWith(k.previousOpen, packet, payloadOffset, packetNumber)
+		return payload, packetNumber, err
+	}
+
+	payload, err := k.openWith(k.nextOpen, packet, payloadOffset, packetNumber)
+	if err != nil {
+		return nil, packetNumber, err
+	}
+
+	// The peer can initiate a key update only after a packet sent with the current keys is acknowledged,
+	// which requires the current keys to be used in both directions.
+	if !k.sentWithCurrentKeys || !k.receivedWithCurrentKeys {
+		return nil, packetNumber, QuicErr.KEY_UPDATE_ERROR
+	}
+	if err := k.update(); err != nil {
+		return nil, packetNumber, err
+	}
+	k.onReceived(packetNumber, now)
+	return payload, packetNumber, nil
+}
+
+func (k *OneRTTKeys) onReceived(packetNumber Packet.PacketNumber, now time.Time) {
+	if k.receivedWithCurrentKeys {
+		return
+	}
+	k.receivedWithCurrentKeys = true
+	k.firstReceivedPacketNumber = packetNumber
+	if k.previousOpen != nil {
+		k.previousOpenExpiry = now.Add(3 * k.PTO)
+	}
+}
+
+// openWith opens the packet with the keys and counts the packets that fail authentication across all keys.
+func (k *OneRTTKeys) openWith(keys *Keys, packet []byte, payloadOffset int, packetNumber Packet.PacketNumber) ([]byte, error) {
+	payload, err := keys.Open(packet, payloadOffset, packetNumber)
+	if err != nil {
+		k.failedOpens++
+		if k.failedOpens > keys.IntegrityLimit {
+			return nil, QuicErr.AEAD_LIMIT_REACHED
+		}
+	}
+	return payload, err
+}
@@ -0,0 +1,269 @@
+package PacketProtection_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	PacketProtection "github.com/udan-jayanith/Quick/packet-protection"
+)
+
+var (
+	clientSecret = bytes.Repeat([]byte{0x0c}, 32)
+	serverSecret = bytes.Repeat([]byte{0x05}, 32)
+)
+
+// https://datatracker.ietf.org/doc/html/rfc9001#appendix-A.5
+func TestNextKeys(t *testing.T) {
+	keys, err := PacketProtection.NewKeys(tls.TLS_CHACHA20_POLY1305_SHA256, Testing.Unhex("9ac312a7f877468ebe69422748ad00a15443f18203a07d6060f688f30f21632b"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	next, err := keys.Next()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := Testing.Unhex("1223504755036d556342ee9361d253421a826c9ecdf3c7148684b36b714881f9")
+	if !bytes.Equal(next.Secret, expected) {
+		t.Fatalf("Test failed.\nExpected secret %x\nGot %x", expected, next.Secret)
+	}
+}
+
+func newOneRTTKeys(t *testing.T, sealSecret, openSecret []byte) *PacketProtection.OneRTTKeys {
+	seal, err := PacketProtection.NewKeys(tls.TLS_AES_128_GCM_SHA256, sealSecret)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	open, err := PacketProtection.NewKeys(tls.TLS_AES_128_GCM_SHA256, openSecret)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	keys, err := PacketProtection.NewOneRTTKeys(seal, open)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return keys
+}
+
+// oneRTTPacket returns a unprotected 1-RTT packet with a empty Destination Connection ID.
+func oneRTTPacket(t *testing.T, packetNumber Packet.PacketNumber) []byte {
+	h := Packet.ShortHeader{}
+	packet, err := h.Append(nil, []byte{byte(packetNumber)})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return append(packet, []byte("payload")...)
+}
+
+func send(t *testing.T, keys *PacketProtection.OneRTTKeys, packetNumber Packet.PacketNumber) []byte {
+	packet, err := keys.Protect(oneRTTPacket(t, packetNumber), 1, packetNumber)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return packet
+}
+
+func receive(keys *PacketProtection.OneRTTKeys, packet []byte, packetNumber Packet.PacketNumber, now time.Time) error {
+	payload, pn, err := keys.Unprotect(append([]byte{}, packet...), 1, packetNumber-1, now)
+	if err == nil && (pn != packetNumber || string(payload) != "payload") {
+		panic("Unexpected packet")
+	}
+	return err
+}
+
+func TestKeyUpdate(t *testing.T) {
+	now := time.Now()
+	client := newOneRTTKeys(t, clientSecret, serverSecret)
+	server := newOneRTTKeys(t, serverSecret, clientSecret)
+
+	if err := client.InitiateKeyUpdate(); err != PacketProtection.KeyUpdateNotAllowed {
+		t.Fatal("Expected", PacketProtection.KeyUpdateNotAllowed, "but got", err)
+	}
+
+	if err := receive(server, send(t, client, 1), 1, now); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(client, send(t, server, 1), 1, now); err != nil {
+		t.Fatal(err.Error())
+	}
+	reordered := send(t, client, 2)
+
+	client.ConfirmHandshake()
+	client.OnPacketAcknowledged(1)
+	if err := client.InitiateKeyUpdate(); err != nil {
+		t.Fatal(err.Error())
+	} else if !client.KeyPhase() {
+		t.Fatal("Expected the key phase to be flipped")
+	}
+
+	// The server updates the keys when it receives a packet of the next key phase.
+	if err := receive(server, send(t, client, 3), 3, now); err != nil {
+		t.Fatal(err.Error())
+	} else if !server.KeyPhase() {
+		t.Fatal("Expected the server to update the keys")
+	} else if err := receive(client, send(t, server, 2), 2, now); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Reordered packets of the previous key phase are unprotected with the previous keys until 3 PTOs pass.
+	if err := receive(server, reordered, 2, now); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(server, reordered, 2, now.Add(3*server.PTO)); err != PacketProtection.DecryptionFailed {
+		t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+	}
+
+	// A subsequent key update requires a acknowledgment of a packet sent with the current keys.
+	if err := client.InitiateKeyUpdate(); err != PacketProtection.KeyUpdateNotAllowed {
+		t.Fatal("Expected", PacketProtection.KeyUpdateNotAllowed, "but got", err)
+	}
+	client.OnPacketAcknowledged(3)
+	if err := client.InitiateKeyUpdate(); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(server, send(t, client, 4), 4, now); err != nil {
+		t.Fatal(err.Error())
+	} else if server.KeyPhase() {
+		t.Fatal("Expected the server to update the keys")
+	}
+}
+
+func TestScheduledKeyUpdate(t *testing.T) {
+	client := newOneRTTKeys(t, clientSecret, serverSecret)
+	client.KeyUpdateInterval = 2
+	client.ConfirmHandshake()
+
+	send(t, client, 1)
+	send(t, client, 2)
+	// The key update is not initiated until a packet sent with the current keys is acknowledged.
+	send(t, client, 3)
+	if client.KeyPhase() {
+		t.Fatal("Expected the key phase not to be flipped")
+	}
+
+	client.OnPacketAcknowledged(1)
+	if packet := send(t, client, 4); !client.KeyPhase() {
+		t.Fatal("Expected the key phase to be flipped")
+	} else if err := receive(newOneRTTKeys(t, serverSecret, clientSecret), packet, 4, time.Now()); err != QuicErr.KEY_UPDATE_ERROR {
+		// The server has not used the keys of the key phase 0 in both directions.
+		t.Fatal("Expected", QuicErr.KEY_UPDATE_ERROR, "but got", err)
+	}
+}
+
+func TestConsecutiveKeyUpdates(t *testing.T) {
+	now := time.Now()
+	client := newOneRTTKeys(t, clientSecret, serverSecret)
+	server := newOneRTTKeys(t, serverSecret, clientSecret)
+
+	if err := receive(server, send(t, client, 1), 1, now); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(client, send(t, server, 1), 1, now); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	client.ConfirmHandshake()
+	client.OnPacketAcknowledged(1)
+	if err := client.InitiateKeyUpdate(); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(server, send(t, client, 2), 2, now); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// The client updates the keys again before the server sends a packet with the updated keys.
+	keys, err := PacketProtection.NewKeys(tls.TLS_AES_128_GCM_SHA256, clientSecret)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for range 2 {
+		if keys, err = keys.Next(); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	packet, err := keys.Protect(oneRTTPacket(t, 3), 1, 3)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(server, packet, 3, now); err != QuicErr.KEY_UPDATE_ERROR {
+		t.Fatal("Expected", QuicErr.KEY_UPDATE_ERROR, "but got", err)
+	}
+}
+
+func newLimitedOneRTTKeys(t *testing.T, sealSecret, openSecret []byte, confidentialityLimit, integrityLimit uint64) *PacketProtection.OneRTTKeys {
+	seal, err := PacketProtection.NewKeys(tls.TLS_AES_128_GCM_SHA256, sealSecret)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	open, err := PacketProtection.NewKeys(tls.TLS_AES_128_GCM_SHA256, openSecret)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	seal.ConfidentialityLimit = confidentialityLimit
+	open.IntegrityLimit = integrityLimit
+
+	keys, err := PacketProtection.NewOneRTTKeys(seal, open)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	keys.KeyUpdateInterval = 0
+	return keys
+}
+
+func TestConfidentialityLimit(t *testing.T) {
+	client := newLimitedOneRTTKeys(t, clientSecret, serverSecret, 8, 1<<52)
+	client.ConfirmHandshake()
+	send(t, client, 1)
+	client.OnPacketAcknowledged(1)
+
+	// The key update is initiated before the confidentiality limit is reached.
+	for pn := Packet.PacketNumber(2); pn <= 8; pn++ {
+		send(t, client, pn)
+	}
+	if !client.KeyPhase() {
+		t.Fatal("Expected the key phase to be flipped")
+	}
+
+	// The confidentiality limit is reached if a key update is not allowed.
+	client = newLimitedOneRTTKeys(t, clientSecret, serverSecret, 8, 1<<52)
+	for pn := Packet.PacketNumber(1); pn <= 8; pn++ {
+		send(t, client, pn)
+	}
+	if _, err := client.Protect(oneRTTPacket(t, 9), 1, 9); err != QuicErr.AEAD_LIMIT_REACHED {
+		t.Fatal("Expected", QuicErr.AEAD_LIMIT_REACHED, "but got", err)
+	}
+}
+
+func TestIntegrityLimit(t *testing.T) {
+	now := time.Now()
+	client := newOneRTTKeys(t, clientSecret, serverSecret)
+	server := newLimitedOneRTTKeys(t, serverSecret, clientSecret, 1<<23, 2)
+
+	forged := func(packetNumber Packet.PacketNumber) []byte {
+		packet := send(t, client, packetNumber)
+		packet[len(packet)-1] ^= 0xff
+		return packet
+	}
+
+	if err := receive(server, send(t, client, 1), 1, now); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(client, send(t, server, 1), 1, now); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(server, forged(2), 2, now); err != PacketProtection.DecryptionFailed {
+		t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+	}
+
+	client.ConfirmHandshake()
+	client.OnPacketAcknowledged(1)
+	if err := client.InitiateKeyUpdate(); err != nil {
+		t.Fatal(err.Error())
+	} else if err := receive(server, send(t, client, 3), 3, now); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Packets that fail authentication are counted across all keys.
+	if err := receive(server, forged(4), 4, now); err != PacketProtection.DecryptionFailed {
+		t.Fatal("Expected", PacketProtection.DecryptionFailed, "but got", err)
+	} else if err := receive(server, forged(5), 5, now); err != QuicErr.AEAD_LIMIT_REACHED {
+		t.Fatal("Expected", QuicErr.AEAD_LIMIT_REACHED, "but got", err)
+	}
+}
@@ -0,0 +1,134 @@
+package PacketProtection
+
+import (
+	"crypto/cipher"
+	"crypto/hkdf"
+	"encoding/binary"
+	"errors"
+	"hash"
+
+	Packet "github.com/udan-jayanith/Quick/packet"
+)
+
+const (
+	// Header protection samples 16 bytes of the packet payload.
+	SampleLength int = 16
+)
+
+var (
+	UnsupportedCipherSuite error = errors.New("Unsupported cipher suite")
+	// DecryptionFailed is returned for packets that cannot be unprotected. The packet must be discarded.
+	DecryptionFailed           error = errors.New("Packet decryption failed")
+	InvalidHeaderProtectionKey error = errors.New("Header protection key has an invalid length")
+	// The Packet Number and the payload together must be at least 4 bytes long so that header protection can be sampled.
+	PacketTooShort error = errors.New("Packet is too short to be protected")
+)
+
+// hkdfExpandLabel is the HKDF-Expand-Label function of TLS 1.3 with a empty context.
+//
+// https://datatracker.ietf.org/doc/html/rfc8446#section-7.1
+func hkdfExpandLabel(h func() hash.Hash, secret []byte, label string, length int) ([]byte, error) {
+	label = "tls13 " + label
+	info := make([]byte, 0, 2+1+len(label)+1)
+	info = binary.BigEndian.AppendUint16(info, uint16(length))
+	info = append(info, byte(len(label)))
+	info = append(info, label...)
+	info = append(info, 0)
+	return hkdf.Expand(h, secret, string(info), length)
+}
+
+// Keys protect packets of a encryption level in one direction.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5
+type Keys struct {
+	// Suite is the TLS 1.3 cipher suite of the keys.
+	Suite uint16
+	// Secret is the traffic secret the keys are derived from.
+	Secret []byte
+
+	// ConfidentialityLimit is the number of packets that can be sealed with the keys.
+	ConfidentialityLimit uint64
+	// IntegrityLimit is the number of packets that can fail authentication.
+	IntegrityLimit uint64
+
+	aead cipher.AEAD
+	iv   []byte
+	hp   HeaderProtector
+
+	sealedPackets uint64
+	failedOpens   uint64
+}
+
+// NewKeys derives the packet protection key, IV and header protection key from the secret of the cipher suite.
+// If the cipher suite is not supported NewKeys returns UnsupportedCipherSuite.
+func NewKeys(suite uint16, secret []byte) (*Keys, error) {
+	cs, ok := cipherSuites[suite]
+	if !ok {
+		return nil, UnsupportedCipherSuite
+	}
+
+	key, err := hkdfExpandLabel(cs.hash, secret, "quic key", cs.keyLength)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := hkdfExpandLabel(cs.hash, secret, "quic iv", NonceLength)
+	if err != nil {
+		return nil, err
+	}
+	hpKey, err := hkdfExpandLabel(cs.hash, secret, "quic hp", cs.keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cs.newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	hp, err := NewHeaderProtector(suite, hpKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keys{
+		Suite:                suite,
+		Secret:               secret,
+		ConfidentialityLimit: cs.confidentialityLimit,
+		IntegrityLimit:       cs.integrityLimit,
+		aead:                 aead,
+		iv:                   iv,
+		hp:                   hp,
+	}, nil
+}
+
+// Protect protects the packet in place and returns it.
+// packet is the unprotected header including the Packet Number followed by the plaintext payload, and packetNumberOffset is the offset of the Packet Number field.
+// Protect appends Overhead bytes to packet, which must be included in the Length field of long headers.
+// If the capacity of packet has room for them the packet is not reallocated.
+func (k *Keys) Protect(packet []byte, packetNumberOffset int, packetNumber Packet.PacketNumber) ([]byte, error) {
+	payloadOffset := packetNumberOffset + int(packet[0]&0b_11) + 1
+	if len(packet) < packetNumberOffset+4 || len(packet) < payloadOffset {
+		return packet, PacketTooShort
+	}
+
+	packet, err := k.Seal(packet, payloadOffset, packetNumber)
+	if err != nil {
+		return packet, err
+	}
+	return packet, ProtectHeader(k.hp, packet, packetNumberOffset)
+}
+
+// Unprotect removes the header protection and the packet protection of the packet in place.
+// largestPacketNumber is the largest packet number successfully processed in the packet number space.
+// Unprotect returns the plaintext payload and the packet number.
+//
+// If the packet cannot be unprotected Unprotect returns DecryptionFailed and the packet must be discarded.
+// If the integrity limit is exceeded Unprotect returns QuicErr.AEAD_LIMIT_REACHED.
+func (k *Keys) Unprotect(packet []byte, packetNumberOffset int, largestPacketNumber Packet.PacketNumber) ([]byte, Packet.PacketNumber, error) {
+	packetNumber, packetNumberLength, err := UnprotectHeader(k.hp, packet, packetNumberOffset, largestPacketNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	payload, err := k.Open(packet, packetNumberOffset+packetNumberLength, packetNumber)
+	return payload, packetNumber, err
+}
@@ -0,0 +1,102 @@
+package Packet
+
+import (
+	"errors"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+)
+
+var (
+	// TrailingGarbage is returned when bytes after a packet of a datagram are not a valid packet.
+	TrailingGarbage error = errors.New("Datagram contains bytes that are not a valid packet")
+	// ConnectionIDMismatch is returned when a coalesced packet has a different Destination Connection ID than the first packet of the datagram.
+	ConnectionIDMismatch error = errors.New("Coalesced packet has a different Destination Connection ID than the first packet")
+)
+
+// CoalescedPacket is one of the packets of a UDP datagram.
+type CoalescedPacket struct {
+	// IsLongHeader reports whether the packet carries a LongHeader or a ShortHeader.
+	IsLongHeader bool
+	LongHeader   LongHeader
+	ShortHeader  ShortHeader
+	// Packet is the whole packet including the header. Packet references the datagram.
+	Packet []byte
+}
+
+func (cp *CoalescedPacket) Type() PacketType {
+	if cp.IsLongHeader {
+		return cp.LongHeader.Type
+	}
+	return OneRTT
+}
+
+func (cp *CoalescedPacket) DestinationConnectionID() ConnectionIdentifier.ConnectionID {
+	if cp.IsLongHeader {
+		return cp.LongHeader.DestinationConnectionID
+	}
+	return cp.ShortHeader.DestinationConnectionID
+}
+
+// SplitDatagram splits a UDP datagram into its coalesced packets.
+// Long header packets are split using their Length field and a short header packet consumes the rest of the datagram.
+// shortHeaderParser is used to parse short header packets. If shortHeaderParser is nil short header packets are considered invalid.
+//
+// If the first packet is invalid SplitDatagram returns InvalidPacketHeader and the datagram must be dropped.
+// If the first packet is not a QUIC version 1 packet SplitDatagram returns the packet consuming the whole datagram and UnsupportedVersion.
+//
+// Receivers SHOULD ignore any subsequent packets with a different Destination Connection ID than the first packet in the datagram.
+// If a subsequent packet is invalid or has a different Destination Connection ID SplitDatagram returns the packets before it
+// and TrailingGarbage or ConnectionIDMismatch. The returned packets can still be processed.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-12.2
+func SplitDatagram(datagram []byte, shortHeaderParser *ShortHeaderParser) ([]CoalescedPacket, error) {
+	packets := make([]CoalescedPacket, 0, 3)
+	b := datagram
+
+	for len(b) > 0 {
+		packet, err := parseCoalescedPacket(b, shortHeaderParser)
+		if len(packets) == 0 {
+			if err == UnsupportedVersion {
+				packet.Packet = b
+				return append(packets, packet), err
+			} else if err != nil {
+				return packets, InvalidPacketHeader
+			}
+		} else if err != nil {
+			return packets, TrailingGarbage
+		} else if !packets[0].DestinationConnectionID().Equal(packet.DestinationConnectionID()) {
+			return packets, ConnectionIDMismatch
+		}
+
+		packets = append(packets, packet)
+		b = b[len(packet.Packet):]
+	}
+	return packets, nil
+}
+
+func parseCoalescedPacket(b []byte, shortHeaderParser *ShortHeaderParser) (CoalescedPacket, error) {
+	packet := CoalescedPacket{
+		IsLongHeader: IsLongHeader(b[0]),
+	}
+
+	if packet.IsLongHeader {
+		h, err := ParseLongHeader(b)
+		packet.LongHeader = h
+		if err != nil {
+			return packet, err
+		}
+		packet.Packet = b[:h.PacketLength()]
+		return packet, nil
+	}
+
+	if shortHeaderParser == nil {
+		return packet, InvalidPacketHeader
+	}
+	h, err := shortHeaderParser.Parse(b)
+	if err != nil {
+		return packet, err
+	}
+	packet.ShortHeader = h
+	packet.Packet = b
+	return packet, nil
+}
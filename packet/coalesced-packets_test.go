@@ -0,0 +1,95 @@
+package Packet_test
+
+import (
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+func appendLongHeaderPacket(t *testing.T, b []byte, packetType Packet.PacketType, dcid ConnectionIdentifier.ConnectionID, payloadLength int) []byte {
+	h := Packet.LongHeader{
+		Type:                    packetType,
+		Version:                 Version.V1,
+		DestinationConnectionID: dcid,
+		SourceConnectionID:      ConnectionIdentifier.ConnectionID{0x0a, 0x0b},
+		Length:                  Packet.PacketNumber(1 + payloadLength),
+	}
+	b, err := h.Append(b, []byte{0x00})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return append(b, make([]byte, payloadLength)...)
+}
+
+func TestSplitDatagram(t *testing.T) {
+	dcid := ConnectionIdentifier.ConnectionID{0x01, 0x02, 0x03, 0x04}
+	parser := Packet.NewShortHeaderParser(Packet.FixedConnectionIDLength(len(dcid)))
+
+	datagram := appendLongHeaderPacket(t, nil, Packet.Initial, dcid, 30)
+	datagram = appendLongHeaderPacket(t, datagram, Packet.Handshake, dcid, 50)
+	shortHeader := Packet.ShortHeader{DestinationConnectionID: dcid}
+	datagram, err := shortHeader.Append(datagram, []byte{0x00})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	datagram = append(datagram, make([]byte, 20)...)
+
+	packets, err := Packet.SplitDatagram(datagram, parser)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if len(packets) != 3 {
+		t.Fatal("Expected 3 packets but got", len(packets))
+	}
+
+	length := 0
+	for i, packetType := range [...]Packet.PacketType{Packet.Initial, Packet.Handshake, Packet.OneRTT} {
+		if packets[i].Type() != packetType {
+			t.Fatal("Expected packet type", packetType, "but got", packets[i].Type())
+		} else if !packets[i].DestinationConnectionID().Equal(dcid) {
+			t.Fatal("Unexpected Destination Connection ID", packets[i].DestinationConnectionID())
+		}
+		length += len(packets[i].Packet)
+	}
+	if length != len(datagram) {
+		t.Fatal("Expected packets to cover", len(datagram), "bytes but they cover", length)
+	}
+}
+
+func TestSplitDatagramErrors(t *testing.T) {
+	dcid := ConnectionIdentifier.ConnectionID{0x01, 0x02, 0x03, 0x04}
+
+	// Trailing zero bytes are not a valid packet.
+	datagram := appendLongHeaderPacket(t, nil, Packet.Initial, dcid, 30)
+	packets, err := Packet.SplitDatagram(append(datagram, make([]byte, 10)...), nil)
+	if err != Packet.TrailingGarbage {
+		t.Fatal("Expected", Packet.TrailingGarbage, "but got", err)
+	} else if len(packets) != 1 {
+		t.Fatal("Expected 1 packet but got", len(packets))
+	}
+
+	// Coalesced packets with different Destination Connection IDs.
+	datagram = appendLongHeaderPacket(t, datagram, Packet.Handshake, ConnectionIdentifier.ConnectionID{0x05}, 30)
+	packets, err = Packet.SplitDatagram(datagram, nil)
+	if err != Packet.ConnectionIDMismatch {
+		t.Fatal("Expected", Packet.ConnectionIDMismatch, "but got", err)
+	} else if len(packets) != 1 {
+		t.Fatal("Expected 1 packet but got", len(packets))
+	}
+
+	// Short header packet without a ShortHeaderParser.
+	if packets, err = Packet.SplitDatagram([]byte{0x40, 0x01, 0x02, 0x03, 0x04, 0x00}, nil); err != Packet.InvalidPacketHeader {
+		t.Fatal("Expected", Packet.InvalidPacketHeader, "but got", err)
+	} else if len(packets) != 0 {
+		t.Fatal("Expected no packets but got", len(packets))
+	}
+
+	// Version Negotiation is needed for unknown versions.
+	unknownVersion := []byte{0xc0, 0x1a, 0x2a, 0x3a, 0x4a, 0x01, 0xaa, 0x01, 0xbb, 0x00, 0x00}
+	if packets, err = Packet.SplitDatagram(unknownVersion, nil); err != Packet.UnsupportedVersion {
+		t.Fatal("Expected", Packet.UnsupportedVersion, "but got", err)
+	} else if len(packets) != 1 || packets[0].LongHeader.Version != 0x1a2a3a4a || len(packets[0].Packet) != len(unknownVersion) {
+		t.Fatal("Expected the packet to consume the whole datagram")
+	}
+}
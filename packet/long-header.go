@@ -0,0 +1,233 @@
+package Packet
+
+import (
+	"encoding/binary"
+	"errors"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	"github.com/udan-jayanith/Quick/varint"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+const (
+	headerFormBit byte = 0b_1000_0000
+	fixedBit      byte = 0b_0100_0000
+	// Retry Integrity Tag is 128 bits long.
+	RetryIntegrityTagLength int = 16
+)
+
+var (
+	// InvalidPacketHeader is returned for packets that must be dropped silently.
+	InvalidPacketHeader error = errors.New("Invalid packet header")
+	// UnsupportedVersion is returned for long header packets of a version other than QUIC version 1.
+	// The header returned with UnsupportedVersion carries only the Version and the connection IDs.
+	UnsupportedVersion error = errors.New("Unsupported QUIC version")
+	InvalidPacketType  error = errors.New("Packet type is not a long header packet type")
+	// Packet numbers are encoded in 1 to 4 bytes.
+	InvalidPacketNumberLength error = errors.New("Packet number must be 1 to 4 bytes long")
+)
+
+/*
+Long Header Packet {
+  Header Form (1) = 1,
+  Fixed Bit (1) = 1,
+  Long Packet Type (2),
+  Type-Specific Bits (4),
+  Version (32),
+  Destination Connection ID Length (8),
+  Destination Connection ID (0..160),
+  Source Connection ID Length (8),
+  Source Connection ID (0..160),
+  Type-Specific Payload (..),
+}
+*/
+
+// LongHeader is the header of Initial, 0-RTT, Handshake and Retry packets.
+type LongHeader struct {
+	Type PacketType
+	// Type-Specific Bits are the 4 least significant bits of the first byte.
+	// For Initial, 0-RTT and Handshake packets they are the Reserved Bits (2) and the Packet Number Length (2), which are protected by header protection.
+	TypeSpecificBits byte
+	Version          Version.QuickVersion
+
+	DestinationConnectionID ConnectionIdentifier.ConnectionID
+	SourceConnectionID      ConnectionIdentifier.ConnectionID
+
+	// Token is the Token of Initial packets and the Retry Token of Retry packets.
+	Token []byte
+	// Length of the rest of the packet (that is, the Packet Number and Payload fields) in bytes.
+	// Retry packets don't have a Length field.
+	Length varint.Int62
+
+	// PacketNumberOffset is the offset of the Packet Number field from the start of the packet.
+	// Header protection samples the packet starting from PacketNumberOffset + 4.
+	PacketNumberOffset int
+}
+
+// IsLongHeader reports whether the first byte of a packet has the Header Form bit set.
+func IsLongHeader(firstByte byte) bool {
+	return firstByte&headerFormBit != 0
+}
+
+// PacketNumberLength returns the length of the Packet Number field in bytes.
+// TypeSpecificBits must be unprotected.
+func (h *LongHeader) PacketNumberLength() int {
+	return int(h.TypeSpecificBits&0b_11) + 1
+}
+
+// ReservedBits returns the Reserved Bits. TypeSpecificBits must be unprotected.
+// An endpoint MUST treat receipt of a packet that has a non-zero value for these bits after removing both packet and header protection as a connection error of type PROTOCOL_VIOLATION.
+func (h *LongHeader) ReservedBits() byte {
+	return h.TypeSpecificBits & 0b_1100
+}
+
+// PacketLength returns the length of the whole packet in bytes.
+func (h *LongHeader) PacketLength() int {
+	return h.PacketNumberOffset + int(h.Length)
+}
+
+// ParseLongHeader parses the long header of the packet at the start of b.
+// The Packet Number field is protected, so ParseLongHeader parses the header up to the Packet Number field.
+// Bytes after the packet are not read, so b can contain coalesced packets. The connection IDs and the Token of the returned header reference b.
+//
+// If the header is invalid ParseLongHeader returns InvalidPacketHeader and the packet must be dropped.
+// If the version is not QUIC version 1 ParseLongHeader returns UnsupportedVersion.
+func ParseLongHeader(b []byte) (LongHeader, error) {
+	h := LongHeader{}
+	if len(b) < 7 || !IsLongHeader(b[0]) {
+		return h, InvalidPacketHeader
+	}
+
+	h.Version = Version.QuickVersion(binary.BigEndian.Uint32(b[1:5]))
+	offset := 5
+
+	// Connection IDs of versions other than QUIC version 1 can be up to 255 bytes long.
+	for _, cid := range [...]*ConnectionIdentifier.ConnectionID{&h.DestinationConnectionID, &h.SourceConnectionID} {
+		if len(b) < offset+1 {
+			return h, InvalidPacketHeader
+		}
+		length := int(b[offset])
+		offset++
+
+		if h.Version == Version.V1 && length > ConnectionIdentifier.MaxConnectionIDLength {
+			return h, InvalidPacketHeader
+		} else if len(b) < offset+length {
+			return h, InvalidPacketHeader
+		}
+		*cid = ConnectionIdentifier.ConnectionID(b[offset : offset+length])
+		offset += length
+	}
+
+	if h.Version != Version.V1 {
+		return h, UnsupportedVersion
+	}
+
+	// Packets containing a zero value for the Fixed Bit are not valid packets in QUIC version 1.
+	if b[0]&fixedBit == 0 {
+		return h, InvalidPacketHeader
+	}
+	h.Type = PacketType((b[0] >> 4) & 0b_11)
+	h.TypeSpecificBits = b[0] & 0b_1111
+
+	if h.Type == Retry {
+		if len(b) < offset+RetryIntegrityTagLength {
+			return h, InvalidPacketHeader
+		}
+		h.Token = b[offset : len(b)-RetryIntegrityTagLength]
+		h.PacketNumberOffset = len(b)
+		return h, nil
+	}
+
+	if h.Type == Initial {
+		tokenLength, n, err := readVarint(b[offset:])
+		if err != nil || varint.Int62(len(b)-offset-n) < tokenLength {
+			return h, InvalidPacketHeader
+		}
+		offset += n
+		h.Token = b[offset : offset+int(tokenLength)]
+		offset += int(tokenLength)
+	}
+
+	length, n, err := readVarint(b[offset:])
+	if err != nil {
+		return h, InvalidPacketHeader
+	}
+	offset += n
+
+	// Length must cover at least a 1 byte long Packet Number.
+	if length < 1 || varint.Int62(len(b)-offset) < length {
+		return h, InvalidPacketHeader
+	}
+	h.Length = length
+	h.PacketNumberOffset = offset
+	return h, nil
+}
+
+// Append appends the long header including the packetNumber to b.
+// packetNumber is the truncated packet number returned by EncodePacketNumber and its length is encoded in the Packet Number Length bits.
+// The Length must include the length of the packetNumber. For Retry packets packetNumber is ignored and the Retry Integrity Tag is not appended.
+//
+// Append sets the PacketNumberOffset relative to the start of the header.
+func (h *LongHeader) Append(b []byte, packetNumber []byte) ([]byte, error) {
+	if h.Type > Retry {
+		return b, InvalidPacketType
+	} else if !h.DestinationConnectionID.IsValid() || !h.SourceConnectionID.IsValid() {
+		return b, ConnectionIdentifier.InvalidConnectionIDLength
+	}
+
+	typeSpecificBits := h.TypeSpecificBits & 0b_1111
+	if h.Type != Retry {
+		if len(packetNumber) < 1 || len(packetNumber) > 4 {
+			return b, InvalidPacketNumberLength
+		}
+		typeSpecificBits = typeSpecificBits&0b_1100 | byte(len(packetNumber)-1)
+	}
+
+	start := len(b)
+	buf := append(b, headerFormBit|fixedBit|byte(h.Type)<<4|typeSpecificBits)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(h.Version))
+	buf = append(buf, byte(len(h.DestinationConnectionID)))
+	buf = append(buf, h.DestinationConnectionID...)
+	buf = append(buf, byte(len(h.SourceConnectionID)))
+	buf = append(buf, h.SourceConnectionID...)
+
+	if h.Type == Retry {
+		h.PacketNumberOffset = len(buf) + len(h.Token) - start
+		return append(buf, h.Token...), nil
+	}
+
+	if h.Type == Initial {
+		v, err := varint.Int62ToVarint(varint.Int62(len(h.Token)))
+		if err != nil {
+			return b, err
+		}
+		buf = append(buf, v...)
+		buf = append(buf, h.Token...)
+	}
+
+	v, err := varint.Int62ToVarint(h.Length)
+	if err != nil {
+		return b, err
+	}
+	buf = append(buf, v...)
+
+	h.TypeSpecificBits = typeSpecificBits
+	h.PacketNumberOffset = len(buf) - start
+	return append(buf, packetNumber...), nil
+}
+
+// readVarint decodes the variable length integer at the start of b and returns the value and it's length in bytes.
+func readVarint(b []byte) (varint.Int62, int, error) {
+	if len(b) == 0 {
+		return 0, 0, varint.IntegerOverflow
+	}
+
+	length := 1 << (b[0] >> 6)
+	if len(b) < length {
+		return 0, 0, varint.IntegerOverflow
+	}
+
+	// VarintToInt62 modifies the slice.
+	v, err := varint.VarintToInt62(append([]byte(nil), b[:length]...))
+	return v, length, err
+}
@@ -0,0 +1,132 @@
+package Packet_test
+
+import (
+	"bytes"
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+func TestLongHeaderAppendAndParse(t *testing.T) {
+	for i, h := range [...]Packet.LongHeader{
+		{
+			Type:                    Packet.Initial,
+			Version:                 Version.V1,
+			DestinationConnectionID: ConnectionIdentifier.ConnectionID{0x83, 0x94, 0xc8, 0xf0, 0x3e, 0x51, 0x57, 0x08},
+			SourceConnectionID:      ConnectionIdentifier.ConnectionID{},
+			Token:                   []byte("token"),
+		},
+		{
+			Type:                    Packet.Handshake,
+			Version:                 Version.V1,
+			DestinationConnectionID: make(ConnectionIdentifier.ConnectionID, ConnectionIdentifier.MaxConnectionIDLength),
+			SourceConnectionID:      ConnectionIdentifier.ConnectionID{0xf0, 0x67, 0xa5, 0x50, 0x2a, 0x42, 0x62, 0xb5},
+		},
+		{
+			Type:                    Packet.ZeroRTT,
+			Version:                 Version.V1,
+			DestinationConnectionID: ConnectionIdentifier.ConnectionID{0x01},
+			SourceConnectionID:      ConnectionIdentifier.ConnectionID{0x02},
+		},
+	} {
+		packetNumber, err := Packet.EncodePacketNumber(0xac5c02, 0xabe8b3)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		payload := []byte("payload")
+		h.Length = Packet.PacketNumber(len(packetNumber) + len(payload))
+
+		// A prefix checks PacketNumberOffset is relative to the start of the header.
+		prefix := []byte{0xff, 0xff}
+		b, err := h.Append(prefix, packetNumber)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		b = append(b, payload...)
+		// A coalesced packet.
+		b = append(b, 0xc0, 0xff)
+
+		newHeader, err := Packet.ParseLongHeader(b[len(prefix):])
+		if err != nil {
+			t.Fatalf("Test %v failed.\n%s", i, err.Error())
+		}
+
+		if newHeader.Type != h.Type || newHeader.Version != h.Version || newHeader.Length != h.Length ||
+			!newHeader.DestinationConnectionID.Equal(h.DestinationConnectionID) || !newHeader.SourceConnectionID.Equal(h.SourceConnectionID) ||
+			!bytes.Equal(newHeader.Token, h.Token) || newHeader.PacketNumberOffset != h.PacketNumberOffset {
+			t.Fatalf("Test %v failed.\nExpected\n%s\nbut got\n%s", i, Testing.ToFormattedJson(h), Testing.ToFormattedJson(newHeader))
+		}
+
+		if newHeader.PacketNumberLength() != len(packetNumber) || newHeader.ReservedBits() != 0 {
+			t.Fatalf("Test %v failed.\nExpected packet number length %v but got %v", i, len(packetNumber), newHeader.PacketNumberLength())
+		}
+
+		if newHeader.PacketLength() != len(b)-len(prefix)-2 {
+			t.Fatalf("Test %v failed.\nExpected packet length %v but got %v", i, len(b)-len(prefix)-2, newHeader.PacketLength())
+		}
+
+		pn := b[len(prefix)+newHeader.PacketNumberOffset:][:newHeader.PacketNumberLength()]
+		if packetNumber, err := Packet.DecodePacketNumber(pn, 0xabe8b3); err != nil || packetNumber != 0xac5c02 {
+			t.Fatalf("Test %v failed.\nExpected packet number %v but got %v", i, 0xac5c02, packetNumber)
+		}
+	}
+}
+
+func TestParseLongHeaderErrors(t *testing.T) {
+	valid := []byte{0xc0, 0x00, 0x00, 0x00, 0x01, 0x01, 0xaa, 0x01, 0xbb, 0x00, 0x02, 0x00, 0x00}
+	if _, err := Packet.ParseLongHeader(valid); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i, testcase := range [...]struct {
+		Input []byte
+		Err   error
+	}{
+		{
+			// Short header.
+			Input: append([]byte{0x40}, valid[1:]...),
+			Err:   Packet.InvalidPacketHeader,
+		},
+		{
+			// Fixed Bit is 0.
+			Input: append([]byte{0x80}, valid[1:]...),
+			Err:   Packet.InvalidPacketHeader,
+		},
+		{
+			// Destination Connection ID longer than 20 bytes.
+			Input: append([]byte{0xc0, 0x00, 0x00, 0x00, 0x01, 21}, make([]byte, 40)...),
+			Err:   Packet.InvalidPacketHeader,
+		},
+		{
+			// Length larger than the packet.
+			Input: valid[:len(valid)-1],
+			Err:   Packet.InvalidPacketHeader,
+		},
+		{
+			// Token Length larger than the packet.
+			Input: []byte{0xc0, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x10, 0x00},
+			Err:   Packet.InvalidPacketHeader,
+		},
+		{
+			Input: []byte{0xc0, 0xff, 0x00, 0x00, 0x1d, 0x00, 0x00},
+			Err:   Packet.UnsupportedVersion,
+		},
+	} {
+		if _, err := Packet.ParseLongHeader(testcase.Input); err != testcase.Err {
+			t.Fatalf("Test %v failed.\nExpected error '%v' but got '%v'", i, testcase.Err, err)
+		}
+	}
+
+	h := Packet.LongHeader{Type: Packet.OneRTT}
+	if _, err := h.Append(nil, []byte{0}); err != Packet.InvalidPacketType {
+		t.Fatal("Expected", Packet.InvalidPacketType, "but got", err)
+	}
+
+	h = Packet.LongHeader{Type: Packet.Handshake}
+	if _, err := h.Append(nil, make([]byte, 5)); err != Packet.InvalidPacketNumberLength {
+		t.Fatal("Expected", Packet.InvalidPacketNumberLength, "but got", err)
+	}
+}
@@ -109,7 +109,8 @@ func DecodePacketNumber(packetNumber []byte, largestPacketNumber PacketNumber) (
 	hwin := win / 2
 	mask := win - 1
 	candidate := (expected & ^mask) | PacketNumber(binary.BigEndian.Uint64(fillUpTo8Bytes(packetNumber)))
-	if candidate <= expected-hwin && candidate < 1<<62-win {
+	// PacketNumber is unsigned, so expected-hwin must not underflow.
+	if expected >= hwin && candidate <= expected-hwin && candidate < 1<<62-win {
 		return candidate + win, nil
 	}
 	if candidate > expected+hwin && candidate >= win {
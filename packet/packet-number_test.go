@@ -17,6 +17,11 @@ var (
 			LargestAckPacketNumber: 0xabe8b3,
 			ExpectedPacketNumber:   0xac5c02,
 		},
+		{
+			PacketNumber:           2,
+			LargestAckPacketNumber: 1,
+			ExpectedPacketNumber:   2,
+		},
 	}
 )
 
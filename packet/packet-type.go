@@ -0,0 +1,40 @@
+package Packet
+
+// PacketType is the type of a QUIC version 1 packet.
+// Long header packet types have the same value as the Long Packet Type bits of the header.
+type PacketType uint8
+
+const (
+	//https://datatracker.ietf.org/doc/html/rfc9000#section-17.2.2
+	Initial PacketType = 0x00 + iota
+	//https://datatracker.ietf.org/doc/html/rfc9000#section-17.2.3
+	ZeroRTT
+	//https://datatracker.ietf.org/doc/html/rfc9000#section-17.2.4
+	Handshake
+	//https://datatracker.ietf.org/doc/html/rfc9000#section-17.2.5
+	Retry
+	// 1-RTT packets use the short header.
+	//
+	//https://datatracker.ietf.org/doc/html/rfc9000#section-17.3.1
+	OneRTT
+)
+
+// PacketNumberSpace returns the packet number space of the packet type.
+// Retry packets don't have a packet number, PacketNumberSpace returns ApplicationDataSpace for them.
+func (pt PacketType) PacketNumberSpace() PacketNumberSpace {
+	switch pt {
+	case Initial:
+		return InitialSpace
+	case Handshake:
+		return HandshakeSpace
+	}
+	return ApplicationDataSpace
+}
+
+// Role is the role of a endpoint in a connection.
+type Role uint8
+
+const (
+	Client Role = 0 + iota
+	Server
+)
@@ -0,0 +1,119 @@
+package Packet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+var (
+	// InvalidRetryIntegrityTag is returned for Retry packets that must be discarded by the client.
+	InvalidRetryIntegrityTag error = errors.New("Retry Integrity Tag is invalid")
+	// The Source Connection ID of a Retry packet MUST NOT be equal to the Destination Connection ID of the packet sent by the client.
+	InvalidRetrySourceConnectionID error = errors.New("Retry Source Connection ID must not be the Destination Connection ID of the client")
+	// A client MUST discard a Retry packet with a zero-length Retry Token field.
+	EmptyRetryToken error = errors.New("Retry Token must not be empty")
+)
+
+// The Retry Integrity Tag of QUIC version 1 is computed with AEAD_AES_128_GCM using this fixed key and nonce.
+//
+// https://datatracker.ietf.org/doc/html/rfc9001#section-5.8
+var (
+	retryKey   = [16]byte{0xbe, 0x0c, 0x69, 0x0b, 0x9f, 0x66, 0x57, 0x5a, 0x1d, 0x76, 0x6b, 0x54, 0xe3, 0x68, 0xc8, 0x4e}
+	retryNonce = [12]byte{0x46, 0x15, 0x99, 0xd3, 0x5d, 0x63, 0x2b, 0xf2, 0x23, 0x98, 0x25, 0xbb}
+	retryAEAD  = newRetryAEAD()
+)
+
+func newRetryAEAD() cipher.AEAD {
+	block, err := aes.NewCipher(retryKey[:])
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return aead
+}
+
+/*
+Retry Pseudo-Packet {
+  ODCID Length (8),
+  Original Destination Connection ID (0..160),
+  Header Form (1) = 1,
+  Fixed Bit (1) = 1,
+  Long Packet Type (2) = 3,
+  Unused (4),
+  Version (32),
+  DCID Len (8),
+  Destination Connection ID (0..160),
+  SCID Len (8),
+  Source Connection ID (0..160),
+  Retry Token (..),
+}
+*/
+
+func retryPseudoPacket(originalDestinationConnectionID ConnectionIdentifier.ConnectionID, retryPacket []byte) []byte {
+	pseudoPacket := make([]byte, 0, 1+len(originalDestinationConnectionID)+len(retryPacket))
+	pseudoPacket = append(pseudoPacket, byte(len(originalDestinationConnectionID)))
+	pseudoPacket = append(pseudoPacket, originalDestinationConnectionID...)
+	return append(pseudoPacket, retryPacket...)
+}
+
+// AppendRetryIntegrityTag appends the Retry Integrity Tag of the retryPacket to b.
+// retryPacket is the Retry packet without the Retry Integrity Tag and originalDestinationConnectionID is the Destination Connection ID of the Initial packet of the client.
+func AppendRetryIntegrityTag(b []byte, originalDestinationConnectionID ConnectionIdentifier.ConnectionID, retryPacket []byte) []byte {
+	return retryAEAD.Seal(b, retryNonce[:], nil, retryPseudoPacket(originalDestinationConnectionID, retryPacket))
+}
+
+// AppendRetry appends a Retry packet responding to a Initial packet with the clientHeader to b.
+// The Retry packet carries the sourceConnectionID chosen by the server, the token and the Retry Integrity Tag.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-17.2.5
+func AppendRetry(b []byte, clientHeader *LongHeader, sourceConnectionID ConnectionIdentifier.ConnectionID, token []byte) ([]byte, error) {
+	if len(token) == 0 {
+		return b, EmptyRetryToken
+	} else if sourceConnectionID.Equal(clientHeader.DestinationConnectionID) {
+		return b, InvalidRetrySourceConnectionID
+	}
+
+	h := LongHeader{
+		Type:                    Retry,
+		Version:                 Version.V1,
+		DestinationConnectionID: clientHeader.SourceConnectionID,
+		SourceConnectionID:      sourceConnectionID,
+		Token:                   token,
+	}
+	start := len(b)
+	buf, err := h.Append(b, nil)
+	if err != nil {
+		return b, err
+	}
+	return AppendRetryIntegrityTag(buf, clientHeader.DestinationConnectionID, buf[start:]), nil
+}
+
+// VerifyRetry parses and verifies a Retry packet received by a client.
+// originalDestinationConnectionID is the Destination Connection ID of the Initial packet of the client.
+//
+// If the Retry Integrity Tag is invalid VerifyRetry returns InvalidRetryIntegrityTag and if the Retry Token is empty it returns EmptyRetryToken.
+// In both cases the packet must be discarded.
+func VerifyRetry(packet []byte, originalDestinationConnectionID ConnectionIdentifier.ConnectionID) (LongHeader, error) {
+	h, err := ParseLongHeader(packet)
+	if err != nil {
+		return h, err
+	} else if h.Type != Retry {
+		return h, InvalidPacketType
+	}
+
+	retryPacket := packet[:len(packet)-RetryIntegrityTagLength]
+	tag := packet[len(packet)-RetryIntegrityTagLength:]
+	if _, err := retryAEAD.Open(nil, retryNonce[:], tag, retryPseudoPacket(originalDestinationConnectionID, retryPacket)); err != nil {
+		return h, InvalidRetryIntegrityTag
+	} else if len(h.Token) == 0 {
+		return h, EmptyRetryToken
+	}
+	return h, nil
+}
@@ -0,0 +1,83 @@
+package Packet_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+// https://datatracker.ietf.org/doc/html/rfc9001#appendix-A.4
+func TestRetryTestVector(t *testing.T) {
+	odcid, _ := hex.DecodeString("8394c8f03e515708")
+	expected, _ := hex.DecodeString("ff000000010008f067a5502a4262b5746f6b656e04a265ba2eff4d829058fb3f0f2496ba")
+	scid, _ := hex.DecodeString("f067a5502a4262b5")
+
+	clientHeader := Packet.LongHeader{
+		Type:                    Packet.Initial,
+		Version:                 Version.V1,
+		DestinationConnectionID: odcid,
+		SourceConnectionID:      ConnectionIdentifier.ConnectionID{},
+	}
+	b, err := Packet.AppendRetry(nil, &clientHeader, scid, []byte("token"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// The Unused bits of the test vector are set.
+	b[0] |= 0x0f
+	tag := Packet.AppendRetryIntegrityTag(nil, odcid, b[:len(b)-Packet.RetryIntegrityTagLength])
+	copy(b[len(b)-Packet.RetryIntegrityTagLength:], tag)
+
+	if !bytes.Equal(b, expected) {
+		t.Fatalf("Test failed.\nExpected %x\nGot %x", expected, b)
+	}
+
+	h, err := Packet.VerifyRetry(expected, odcid)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if string(h.Token) != "token" || !h.SourceConnectionID.Equal(scid) {
+		t.Fatal("Unexpected Retry header", h)
+	}
+}
+
+func TestVerifyRetryErrors(t *testing.T) {
+	odcid := ConnectionIdentifier.ConnectionID{0x01, 0x02, 0x03, 0x04}
+	clientHeader := Packet.LongHeader{
+		Type:                    Packet.Initial,
+		Version:                 Version.V1,
+		DestinationConnectionID: odcid,
+		SourceConnectionID:      ConnectionIdentifier.ConnectionID{0x0a},
+	}
+
+	if _, err := Packet.AppendRetry(nil, &clientHeader, odcid, []byte("token")); err != Packet.InvalidRetrySourceConnectionID {
+		t.Fatal("Expected", Packet.InvalidRetrySourceConnectionID, "but got", err)
+	} else if _, err := Packet.AppendRetry(nil, &clientHeader, ConnectionIdentifier.ConnectionID{0x0b}, nil); err != Packet.EmptyRetryToken {
+		t.Fatal("Expected", Packet.EmptyRetryToken, "but got", err)
+	}
+
+	b, err := Packet.AppendRetry(nil, &clientHeader, ConnectionIdentifier.ConnectionID{0x0b}, []byte("token"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := Packet.VerifyRetry(b, ConnectionIdentifier.ConnectionID{0x01}); err != Packet.InvalidRetryIntegrityTag {
+		t.Fatal("Expected", Packet.InvalidRetryIntegrityTag, "but got", err)
+	}
+	b[len(b)-1] ^= 0xff
+	if _, err := Packet.VerifyRetry(b, odcid); err != Packet.InvalidRetryIntegrityTag {
+		t.Fatal("Expected", Packet.InvalidRetryIntegrityTag, "but got", err)
+	}
+
+	// A Retry packet with a empty token is discarded even with a valid tag.
+	h := Packet.LongHeader{Type: Packet.Retry, Version: Version.V1, DestinationConnectionID: ConnectionIdentifier.ConnectionID{0x0a}, SourceConnectionID: ConnectionIdentifier.ConnectionID{0x0b}}
+	b, err = h.Append(nil, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	b = Packet.AppendRetryIntegrityTag(b, odcid, b)
+	if _, err := Packet.VerifyRetry(b, odcid); err != Packet.EmptyRetryToken {
+		t.Fatal("Expected", Packet.EmptyRetryToken, "but got", err)
+	}
+}
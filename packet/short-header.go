@@ -0,0 +1,141 @@
+package Packet
+
+import (
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+)
+
+const (
+	spinBit     byte = 0b_0010_0000
+	keyPhaseBit byte = 0b_0000_0100
+)
+
+/*
+1-RTT Packet {
+  Header Form (1) = 0,
+  Fixed Bit (1) = 1,
+  Spin Bit (1),
+  Reserved Bits (2),
+  Key Phase (1),
+  Packet Number Length (2),
+  Destination Connection ID (0..160),
+  Packet Number (8..32),
+  Packet Payload (8..),
+}
+*/
+
+// ShortHeader is the header of 1-RTT packets.
+// A 1-RTT packet extends to the end of the UDP datagram.
+type ShortHeader struct {
+	// The latency spin bit enables passive latency monitoring from observation points on the network path throughout the duration of a connection.
+	SpinBit bool
+	// ProtectedBits are the 5 least significant bits of the first byte.
+	// They are the Reserved Bits (2), the Key Phase (1) and the Packet Number Length (2), which are protected by header protection.
+	ProtectedBits byte
+
+	DestinationConnectionID ConnectionIdentifier.ConnectionID
+
+	// PacketNumberOffset is the offset of the Packet Number field from the start of the packet.
+	PacketNumberOffset int
+}
+
+// KeyPhase returns the Key Phase bit. ProtectedBits must be unprotected.
+func (h *ShortHeader) KeyPhase() bool {
+	return KeyPhase(h.ProtectedBits)
+}
+
+// SetKeyPhase sets the Key Phase bit.
+func (h *ShortHeader) SetKeyPhase(v bool) {
+	h.ProtectedBits = SetKeyPhase(h.ProtectedBits, v)
+}
+
+// KeyPhase reports whether the unprotected first byte of a 1-RTT packet has the Key Phase bit set.
+func KeyPhase(firstByte byte) bool {
+	return firstByte&keyPhaseBit != 0
+}
+
+// SetKeyPhase returns the first byte of a 1-RTT packet with the Key Phase bit set to v.
+func SetKeyPhase(firstByte byte, v bool) byte {
+	if v {
+		return firstByte | keyPhaseBit
+	}
+	return firstByte &^ keyPhaseBit
+}
+
+// PacketNumberLength returns the length of the Packet Number field in bytes. ProtectedBits must be unprotected.
+func (h *ShortHeader) PacketNumberLength() int {
+	return int(h.ProtectedBits&0b_11) + 1
+}
+
+// ReservedBits returns the Reserved Bits. ProtectedBits must be unprotected.
+func (h *ShortHeader) ReservedBits() byte {
+	return h.ProtectedBits & 0b_1_1000
+}
+
+// Append appends the short header including the packetNumber to b.
+// packetNumber is the truncated packet number returned by EncodePacketNumber and its length is encoded in the Packet Number Length bits.
+//
+// Append sets the PacketNumberOffset relative to the start of the header.
+func (h *ShortHeader) Append(b []byte, packetNumber []byte) ([]byte, error) {
+	if !h.DestinationConnectionID.IsValid() {
+		return b, ConnectionIdentifier.InvalidConnectionIDLength
+	} else if len(packetNumber) < 1 || len(packetNumber) > 4 {
+		return b, InvalidPacketNumberLength
+	}
+	h.ProtectedBits = h.ProtectedBits&0b_1_1100 | byte(len(packetNumber)-1)
+
+	firstByte := fixedBit | h.ProtectedBits
+	if h.SpinBit {
+		firstByte |= spinBit
+	}
+
+	buf := append(b, firstByte)
+	buf = append(buf, h.DestinationConnectionID...)
+	h.PacketNumberOffset = 1 + len(h.DestinationConnectionID)
+	return append(buf, packetNumber...), nil
+}
+
+// ConnectionIDLength returns the length of the local connection ID at the start of b.
+// If b doesn't start with a local connection ID ConnectionIDLength returns false.
+type ConnectionIDLength func(b []byte) (int, bool)
+
+// FixedConnectionIDLength returns a ConnectionIDLength for endpoints that issue connection IDs of a single length.
+func FixedConnectionIDLength(length int) ConnectionIDLength {
+	return func(b []byte) (int, bool) {
+		return length, len(b) >= length
+	}
+}
+
+// ShortHeaderParser parses short headers.
+// The length of the Destination Connection ID is not encoded in short headers, so it is resolved by a ConnectionIDLength.
+type ShortHeaderParser struct {
+	connectionIDLength ConnectionIDLength
+}
+
+func NewShortHeaderParser(connectionIDLength ConnectionIDLength) *ShortHeaderParser {
+	return &ShortHeaderParser{
+		connectionIDLength: connectionIDLength,
+	}
+}
+
+// Parse parses the short header of the packet at the start of b.
+// The Packet Number field is protected, so Parse parses the header up to the Packet Number field.
+// The Destination Connection ID of the returned header references b.
+//
+// If the header is invalid or the Destination Connection ID is unknown Parse returns InvalidPacketHeader and the packet must be dropped.
+func (p *ShortHeaderParser) Parse(b []byte) (ShortHeader, error) {
+	h := ShortHeader{}
+	if len(b) < 1 || IsLongHeader(b[0]) || b[0]&fixedBit == 0 {
+		return h, InvalidPacketHeader
+	}
+	h.SpinBit = b[0]&spinBit != 0
+	h.ProtectedBits = b[0] & 0b_1_1111
+
+	length, ok := p.connectionIDLength(b[1:])
+	if !ok || length < 0 || length > ConnectionIdentifier.MaxConnectionIDLength || len(b) < 1+length+1 {
+		return h, InvalidPacketHeader
+	}
+
+	h.DestinationConnectionID = ConnectionIdentifier.ConnectionID(b[1 : 1+length])
+	h.PacketNumberOffset = 1 + length
+	return h, nil
+}
@@ -0,0 +1,80 @@
+package Packet_test
+
+import (
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+)
+
+func TestShortHeaderAppendAndParse(t *testing.T) {
+	h := Packet.ShortHeader{
+		SpinBit:                 true,
+		DestinationConnectionID: ConnectionIdentifier.ConnectionID{0xf0, 0x67, 0xa5, 0x50, 0x2a, 0x42, 0x62, 0xb5},
+	}
+	h.SetKeyPhase(true)
+
+	packetNumber, err := Packet.EncodePacketNumber(0xac5c02, 0xabe8b3)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	b, err := h.Append(nil, packetNumber)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	b = append(b, []byte("payload")...)
+
+	parser := Packet.NewShortHeaderParser(Packet.FixedConnectionIDLength(8))
+	newHeader, err := parser.Parse(b)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if newHeader.SpinBit != h.SpinBit || newHeader.ProtectedBits != h.ProtectedBits ||
+		!newHeader.DestinationConnectionID.Equal(h.DestinationConnectionID) || newHeader.PacketNumberOffset != h.PacketNumberOffset {
+		t.Fatalf("Expected\n%s\nbut got\n%s", Testing.ToFormattedJson(h), Testing.ToFormattedJson(newHeader))
+	} else if !newHeader.KeyPhase() || newHeader.ReservedBits() != 0 || newHeader.PacketNumberLength() != len(packetNumber) {
+		t.Fatalf("Unexpected protected bits %b", newHeader.ProtectedBits)
+	}
+
+	pn := b[newHeader.PacketNumberOffset:][:newHeader.PacketNumberLength()]
+	if packetNumber, err := Packet.DecodePacketNumber(pn, 0xabe8b3); err != nil || packetNumber != 0xac5c02 {
+		t.Fatal("Expected packet number", 0xac5c02, "but got", packetNumber)
+	}
+
+	h.SetKeyPhase(false)
+	if h.KeyPhase() {
+		t.Fatal("Expected the Key Phase bit to be 0")
+	}
+}
+
+func TestShortHeaderParserErrors(t *testing.T) {
+	parser := Packet.NewShortHeaderParser(func(b []byte) (int, bool) {
+		// Only connection IDs starting with 0x01 are issued by this endpoint.
+		if len(b) == 0 || b[0] != 0x01 {
+			return 0, false
+		}
+		return 4, true
+	})
+
+	if _, err := parser.Parse([]byte{0x40, 0x01, 0x02, 0x03, 0x04, 0x00}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i, input := range [][]byte{
+		// Long header.
+		{0xc0, 0x01, 0x02, 0x03, 0x04, 0x00},
+		// Fixed Bit is 0.
+		{0x00, 0x01, 0x02, 0x03, 0x04, 0x00},
+		// Unknown connection ID.
+		{0x40, 0x02, 0x02, 0x03, 0x04, 0x00},
+		// Missing Packet Number.
+		{0x40, 0x01, 0x02, 0x03, 0x04},
+	} {
+		if _, err := parser.Parse(input); err != Packet.InvalidPacketHeader {
+			t.Fatalf("Test %v failed.\nExpected error '%v' but got '%v'", i, Packet.InvalidPacketHeader, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package Packet
+
+import (
+	"encoding/binary"
+	"errors"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+var (
+	// VersionNegotiationIgnored is returned for Version Negotiation packets that must be ignored by the client.
+	VersionNegotiationIgnored error = errors.New("Version Negotiation packet must be ignored")
+	NoSupportedVersions       error = errors.New("Version Negotiation packet must list at least one supported version")
+)
+
+/*
+Version Negotiation Packet {
+  Header Form (1) = 1,
+  Unused (7),
+  Version (32) = 0,
+  Destination Connection ID Length (8),
+  Destination Connection ID (0..2040),
+  Source Connection ID Length (8),
+  Source Connection ID (0..2040),
+  Supported Version (32) ...,
+}
+*/
+
+// A Version Negotiation packet is sent by a server in response to a client packet that contains a version that is not supported by the server.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-17.2.1
+type VersionNegotiationPacket struct {
+	DestinationConnectionID ConnectionIdentifier.ConnectionID
+	SourceConnectionID      ConnectionIdentifier.ConnectionID
+	SupportedVersions       []Version.QuickVersion
+}
+
+// IsVersionNegotiation reports whether the packet is a Version Negotiation packet.
+func IsVersionNegotiation(b []byte) bool {
+	return len(b) >= 5 && IsLongHeader(b[0]) && binary.BigEndian.Uint32(b[1:5]) == uint32(Version.VersionNegotiation)
+}
+
+// AppendVersionNegotiation appends a Version Negotiation packet responding to a client packet with the clientHeader to b.
+// The connection IDs of the client are echoed, so the Destination Connection ID of the Version Negotiation packet is the Source Connection ID of the client.
+// If supportedVersions is empty AppendVersionNegotiation returns b and NoSupportedVersions.
+//
+// An endpoint MUST NOT send a Version Negotiation packet in response to receiving a Version Negotiation packet.
+func AppendVersionNegotiation(b []byte, clientHeader *LongHeader, supportedVersions []Version.QuickVersion) ([]byte, error) {
+	if len(supportedVersions) == 0 {
+		return b, NoSupportedVersions
+	}
+
+	// The server SHOULD set the most significant bit of the Unused field to 1 so that Version Negotiation packets appear to have the Fixed Bit field.
+	buf := append(b, headerFormBit|fixedBit)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(Version.VersionNegotiation))
+	buf = append(buf, byte(len(clientHeader.SourceConnectionID)))
+	buf = append(buf, clientHeader.SourceConnectionID...)
+	buf = append(buf, byte(len(clientHeader.DestinationConnectionID)))
+	buf = append(buf, clientHeader.DestinationConnectionID...)
+	for _, v := range supportedVersions {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(v))
+	}
+	return buf, nil
+}
+
+// ParseVersionNegotiation parses a Version Negotiation packet.
+// If the packet is not a valid Version Negotiation packet ParseVersionNegotiation returns InvalidPacketHeader.
+func ParseVersionNegotiation(b []byte) (VersionNegotiationPacket, error) {
+	vn := VersionNegotiationPacket{}
+	if !IsVersionNegotiation(b) {
+		return vn, InvalidPacketHeader
+	}
+
+	offset := 5
+	for _, cid := range [...]*ConnectionIdentifier.ConnectionID{&vn.DestinationConnectionID, &vn.SourceConnectionID} {
+		if len(b) < offset+1 || len(b) < offset+1+int(b[offset]) {
+			return vn, InvalidPacketHeader
+		}
+		length := int(b[offset])
+		offset++
+		*cid = ConnectionIdentifier.ConnectionID(b[offset : offset+length])
+		offset += length
+	}
+
+	versions := b[offset:]
+	if len(versions) == 0 || len(versions)%4 != 0 {
+		return vn, InvalidPacketHeader
+	}
+	for i := 0; i < len(versions); i += 4 {
+		vn.SupportedVersions = append(vn.SupportedVersions, Version.QuickVersion(binary.BigEndian.Uint32(versions[i:])))
+	}
+	return vn, nil
+}
+
+// Validate validates a Version Negotiation packet received by a client that sent a packet with the clientHeader.
+//
+// If the connection IDs are not the echoed connection IDs of the client Validate returns InvalidPacketHeader.
+// A client MUST discard a Version Negotiation packet that lists the QUIC version selected by the client, so Validate returns VersionNegotiationIgnored for it.
+func (vn *VersionNegotiationPacket) Validate(clientHeader *LongHeader) error {
+	if !vn.DestinationConnectionID.Equal(clientHeader.SourceConnectionID) || !vn.SourceConnectionID.Equal(clientHeader.DestinationConnectionID) {
+		return InvalidPacketHeader
+	}
+
+	for _, v := range vn.SupportedVersions {
+		if v == clientHeader.Version {
+			return VersionNegotiationIgnored
+		}
+	}
+	return nil
+}
+
+// SelectVersion returns the first version of clientVersions that is supported by the server.
+// If there is no such version SelectVersion returns false.
+func (vn *VersionNegotiationPacket) SelectVersion(clientVersions []Version.QuickVersion) (Version.QuickVersion, bool) {
+	for _, v := range clientVersions {
+		for _, supported := range vn.SupportedVersions {
+			if v == supported {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package Packet_test
+
+import (
+	"testing"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	Version "github.com/udan-jayanith/Quick/version"
+)
+
+func TestVersionNegotiation(t *testing.T) {
+	clientHeader := Packet.LongHeader{
+		Type:                    Packet.Initial,
+		Version:                 0x1a2a3a4a,
+		DestinationConnectionID: ConnectionIdentifier.ConnectionID{0x01, 0x02, 0x03, 0x04},
+		SourceConnectionID:      ConnectionIdentifier.ConnectionID{0x0a, 0x0b},
+	}
+
+	b, err := Packet.AppendVersionNegotiation(nil, &clientHeader, Version.SupportedVersions[:])
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if !Packet.IsVersionNegotiation(b) {
+		t.Fatal("Expected a Version Negotiation packet")
+	}
+
+	vn, err := Packet.ParseVersionNegotiation(b)
+	if err != nil {
+		t.Fatal(err.Error())
+	} else if !vn.DestinationConnectionID.Equal(clientHeader.SourceConnectionID) || !vn.SourceConnectionID.Equal(clientHeader.DestinationConnectionID) {
+		t.Fatal("Expected the connection IDs of the client to be echoed")
+	} else if err := vn.Validate(&clientHeader); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if v, ok := vn.SelectVersion([]Version.QuickVersion{0x1a2a3a4a, Version.V1}); !ok || v != Version.V1 {
+		t.Fatal("Expected", Version.V1, "but got", v)
+	}
+
+	// A Version Negotiation packet listing the version of the client is ignored.
+	clientHeader.Version = Version.V1
+	if err := vn.Validate(&clientHeader); err != Packet.VersionNegotiationIgnored {
+		t.Fatal("Expected", Packet.VersionNegotiationIgnored, "but got", err)
+	}
+
+	clientHeader.SourceConnectionID = ConnectionIdentifier.ConnectionID{0x0c}
+	if err := vn.Validate(&clientHeader); err != Packet.InvalidPacketHeader {
+		t.Fatal("Expected", Packet.InvalidPacketHeader, "but got", err)
+	}
+}
+
+func TestParseVersionNegotiationErrors(t *testing.T) {
+	clientHeader := Packet.LongHeader{
+		DestinationConnectionID: ConnectionIdentifier.ConnectionID{0x01},
+		SourceConnectionID:      ConnectionIdentifier.ConnectionID{0x02},
+	}
+	if _, err := Packet.AppendVersionNegotiation(nil, &clientHeader, nil); err != Packet.NoSupportedVersions {
+		t.Fatal("Expected", Packet.NoSupportedVersions, "but got", err)
+	}
+
+	b, err := Packet.AppendVersionNegotiation(nil, &clientHeader, []Version.QuickVersion{Version.V1})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for _, packet := range [...][]byte{b[:len(b)-4], b[:len(b)-1], b[:6], append(b[:len(b):len(b)], 0x00)} {
+		if _, err := Packet.ParseVersionNegotiation(packet); err != Packet.InvalidPacketHeader {
+			t.Fatal("Expected", Packet.InvalidPacketHeader, "but got", err)
+		}
+	}
+}
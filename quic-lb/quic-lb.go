@@ -0,0 +1,338 @@
+package QuicLB
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"sync"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+)
+
+// QUIC-LB (draft-ietf-quic-load-balancers) encodes a server ID into the connection IDs a server issues, so a load balancer can route packets from the connection ID alone.
+// A QUIC-LB connection ID is the first octet followed by the server ID and the nonce, plaintext or encrypted.
+//
+//	First Octet (8) = Config Rotation (3) || CID Len or Random Bits (5)
+
+const (
+	// MaxConfigID is the largest config rotation codepoint a Config can use.
+	MaxConfigID uint8 = 6
+	// UnroutableConfigID is the config rotation codepoint of connection IDs that are not routable by the server ID.
+	UnroutableConfigID uint8 = 7
+	// KeyLength is the length of the AES-128 key of a encrypted Config.
+	KeyLength int = 16
+
+	MinServerIDLength int = 1
+	MaxServerIDLength int = 15
+	MinNonceLength    int = 4
+	MaxNonceLength    int = 18
+	// MaxPlaintextLength is the largest sum of the server ID length and the nonce length.
+	MaxPlaintextLength int = 19
+)
+
+var (
+	InvalidConfig     error = errors.New("Invalid QUIC-LB configuration")
+	InvalidServerID   error = errors.New("Server ID length does not match the QUIC-LB configuration")
+	UnknownConfigID   error = errors.New("Unknown QUIC-LB config rotation codepoint")
+	UnroutableCID     error = errors.New("Connection ID is not routable by the server ID")
+	ConnectionIDShort error = errors.New("Connection ID is too short for the QUIC-LB configuration")
+	NoncesExhausted   error = errors.New("Every nonce of the QUIC-LB configuration is used")
+)
+
+// Config is a QUIC-LB configuration shared by the load balancer and the servers behind it.
+type Config struct {
+	// ConfigID is the config rotation codepoint, 0-6.
+	ConfigID       uint8
+	ServerIDLength int
+	NonceLength    int
+	// Key is the 16 bytes AES-128 key. The server ID and the nonce are plaintext if Key is nil.
+	Key []byte
+	// LengthSelfDescription encodes the length of the connection ID in the first octet.
+	LengthSelfDescription bool
+}
+
+func (c *Config) validate() error {
+	switch {
+	case c.ConfigID > MaxConfigID:
+		return InvalidConfig
+	case c.ServerIDLength < MinServerIDLength || c.ServerIDLength > MaxServerIDLength:
+		return InvalidConfig
+	case c.NonceLength < MinNonceLength || c.NonceLength > MaxNonceLength:
+		return InvalidConfig
+	case c.plaintextLength() > MaxPlaintextLength:
+		return InvalidConfig
+	case c.Key != nil && len(c.Key) != KeyLength:
+		return InvalidConfig
+	}
+	return nil
+}
+
+func (c *Config) plaintextLength() int {
+	return c.ServerIDLength + c.NonceLength
+}
+
+// ConnectionIDLength returns the length of the connection IDs of the Config.
+func (c *Config) ConnectionIDLength() int {
+	return 1 + c.plaintextLength()
+}
+
+// codec encodes and decodes the server ID and the nonce of a Config.
+type codec struct {
+	config Config
+	block  cipher.Block
+}
+
+func newCodec(config Config) (*codec, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	c := &codec{
+		config: config,
+	}
+	if config.Key != nil {
+		block, err := aes.NewCipher(config.Key)
+		if err != nil {
+			return nil, err
+		}
+		c.block = block
+	}
+	return c, nil
+}
+
+// firstOctet returns the first octet of a connection ID, the config rotation codepoint followed by the length or random bits.
+func (c *codec) firstOctet() (byte, error) {
+	if c.config.LengthSelfDescription {
+		return c.config.ConfigID<<5 | byte(c.config.ConnectionIDLength()-1), nil
+	}
+	var b [1]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return c.config.ConfigID<<5 | b[0]&0x1f, nil
+}
+
+// connectionID returns the connection ID of the plaintext, the server ID followed by the nonce.
+func (c *codec) connectionID(firstOctet byte, plaintext []byte) ConnectionIdentifier.ConnectionID {
+	cid := make(ConnectionIdentifier.ConnectionID, 0, c.config.ConnectionIDLength())
+	cid = append(cid, firstOctet)
+	return append(cid, c.encode(plaintext)...)
+}
+
+// encode encodes the plaintext, the server ID followed by the nonce.
+func (c *codec) encode(plaintext []byte) []byte {
+	switch {
+	case c.block == nil:
+		return plaintext
+	case len(plaintext) == aes.BlockSize:
+		ciphertext := make([]byte, aes.BlockSize)
+		c.block.Encrypt(ciphertext, plaintext)
+		return ciphertext
+	}
+
+	left, right := split(plaintext)
+	c.pass(right, left, 1, false)
+	c.pass(left, right, 2, true)
+	c.pass(right, left, 3, false)
+	c.pass(left, right, 4, true)
+	return merge(left, right, len(plaintext))
+}
+
+// decode reverses encode.
+func (c *codec) decode(ciphertext []byte) []byte {
+	switch {
+	case c.block == nil:
+		return ciphertext
+	case len(ciphertext) == aes.BlockSize:
+		plaintext := make([]byte, aes.BlockSize)
+		c.block.Decrypt(plaintext, ciphertext)
+		return plaintext
+	}
+
+	left, right := split(ciphertext)
+	c.pass(left, right, 4, true)
+	c.pass(right, left, 3, false)
+	c.pass(left, right, 2, true)
+	c.pass(right, left, 1, false)
+	return merge(left, right, len(ciphertext))
+}
+
+// pass is a round of the four-pass Feistel network. pass XORs the half with the AES-ECB encryption of the other half expanded.
+// When the plaintext has a odd number of octets the halves share a octet, the left half owns its high 4 bits and the right half owns its low 4 bits.
+func (c *codec) pass(half, other []byte, n byte, isLeft bool) {
+	var expanded [aes.BlockSize]byte
+	copy(expanded[:], other)
+	expanded[aes.BlockSize-2] = byte(c.config.plaintextLength())
+	expanded[aes.BlockSize-1] = n
+	c.block.Encrypt(expanded[:], expanded[:])
+
+	for i := range half {
+		half[i] ^= expanded[i]
+	}
+	if c.odd() {
+		if isLeft {
+			half[len(half)-1] &= 0xf0
+		} else {
+			half[0] &= 0x0f
+		}
+	}
+}
+
+func (c *codec) odd() bool {
+	return c.config.plaintextLength()%2 == 1
+}
+
+// split splits b into two halves. When b has a odd number of octets the middle octet is in both halves, the low 4 bits cleared in the left half and the high 4 bits cleared in the right half.
+func split(b []byte) (left, right []byte) {
+	halfLength := (len(b) + 1) / 2
+	left = append([]byte{}, b[:halfLength]...)
+	right = append([]byte{}, b[len(b)-halfLength:]...)
+	if len(b)%2 == 1 {
+		left[halfLength-1] &= 0xf0
+		right[0] &= 0x0f
+	}
+	return left, right
+}
+
+// merge reverses split.
+func merge(left, right []byte, length int) []byte {
+	b := make([]byte, 0, length)
+	b = append(b, left...)
+	if length%2 == 1 {
+		b[len(b)-1] |= right[0]
+		return append(b, right[1:]...)
+	}
+	return append(b, right...)
+}
+
+// Generator is a ConnectionIDGenerator that encodes the server ID of a server into the connection IDs.
+// Generator is safe for concurrent use.
+type Generator struct {
+	codec    *codec
+	serverID []byte
+
+	mu sync.Mutex
+	// nonce is a counter started at a random value, so the nonces of a Generator do not repeat.
+	nonce     *big.Int
+	remaining *big.Int
+}
+
+var _ ConnectionIdentifier.ConnectionIDGenerator = (*Generator)(nil)
+
+// NewGenerator returns a Generator of the server with the serverID.
+func NewGenerator(config Config, serverID []byte) (*Generator, error) {
+	codec, err := newCodec(config)
+	if err != nil {
+		return nil, err
+	} else if len(serverID) != config.ServerIDLength {
+		return nil, InvalidServerID
+	}
+
+	nonces := new(big.Int).Lsh(big.NewInt(1), uint(8*config.NonceLength))
+	nonce, err := rand.Int(rand.Reader, nonces)
+	if err != nil {
+		return nil, err
+	}
+	return &Generator{
+		codec:     codec,
+		serverID:  append([]byte{}, serverID...),
+		nonce:     nonce,
+		remaining: nonces,
+	}, nil
+}
+
+func (g *Generator) ConnectionIDLength() int {
+	return g.codec.config.ConnectionIDLength()
+}
+
+func (g *Generator) GenerateConnectionID() (ConnectionIdentifier.ConnectionID, error) {
+	// The first octet is built before a nonce is taken, so a failure does not use a nonce.
+	firstOctet, err := g.codec.firstOctet()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &g.codec.config
+	plaintext := make([]byte, config.plaintextLength())
+	copy(plaintext, g.serverID)
+
+	g.mu.Lock()
+	if g.remaining.Sign() == 0 {
+		g.mu.Unlock()
+		return nil, NoncesExhausted
+	}
+	g.nonce.FillBytes(plaintext[config.ServerIDLength:])
+	g.nonce.Add(g.nonce, big.NewInt(1))
+	if g.nonce.BitLen() > 8*config.NonceLength {
+		g.nonce.SetInt64(0)
+	}
+	g.remaining.Sub(g.remaining, big.NewInt(1))
+	g.mu.Unlock()
+
+	return g.codec.connectionID(firstOctet, plaintext), nil
+}
+
+// EncodeConnectionID returns the connection ID of the config that encodes the serverID and the nonce.
+// The nonce must not repeat, servers that do not choose their own nonces use a Generator.
+func EncodeConnectionID(config Config, serverID, nonce []byte) (ConnectionIdentifier.ConnectionID, error) {
+	codec, err := newCodec(config)
+	if err != nil {
+		return nil, err
+	} else if len(serverID) != config.ServerIDLength {
+		return nil, InvalidServerID
+	} else if len(nonce) != config.NonceLength {
+		return nil, InvalidConfig
+	}
+
+	firstOctet, err := codec.firstOctet()
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, 0, config.plaintextLength())
+	plaintext = append(plaintext, serverID...)
+	return codec.connectionID(firstOctet, append(plaintext, nonce...)), nil
+}
+
+// Decoder recovers the server ID from the connection IDs of servers that use its configurations.
+// A load balancer routes a packet to the server with the server ID.
+type Decoder struct {
+	codecs [MaxConfigID + 1]*codec
+}
+
+// NewDecoder returns a Decoder of the configs. Each config must have a distinct ConfigID.
+func NewDecoder(configs ...Config) (*Decoder, error) {
+	d := &Decoder{}
+	for _, config := range configs {
+		codec, err := newCodec(config)
+		if err != nil {
+			return nil, err
+		} else if d.codecs[config.ConfigID] != nil {
+			return nil, InvalidConfig
+		}
+		d.codecs[config.ConfigID] = codec
+	}
+	return d, nil
+}
+
+// ServerID returns the server ID encoded in the cid.
+// ServerID returns UnroutableCID or UnknownConfigID if the cid cannot be routed by the server ID, the load balancer then uses a fallback routing.
+func (d *Decoder) ServerID(cid ConnectionIdentifier.ConnectionID) ([]byte, error) {
+	if len(cid) == 0 {
+		return nil, ConnectionIDShort
+	}
+	configID := cid[0] >> 5
+	if configID == UnroutableConfigID {
+		return nil, UnroutableCID
+	}
+	codec := d.codecs[configID]
+	if codec == nil {
+		return nil, UnknownConfigID
+	} else if len(cid) < codec.config.ConnectionIDLength() {
+		return nil, ConnectionIDShort
+	}
+
+	ciphertext := append([]byte{}, cid[1:codec.config.ConnectionIDLength()]...)
+	plaintext := codec.decode(ciphertext)
+	return plaintext[:codec.config.ServerIDLength], nil
+}
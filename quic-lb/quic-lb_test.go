@@ -0,0 +1,220 @@
+package QuicLB_test
+
+import (
+	"bytes"
+	"testing"
+
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+
+	ConnectionIDManager "github.com/udan-jayanith/Quick/connection-id-manager"
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	QuicLB "github.com/udan-jayanith/Quick/quic-lb"
+)
+
+var (
+	key = []byte{0xfd, 0xf7, 0x26, 0xa9, 0x89, 0x3e, 0xc0, 0x5c, 0x06, 0x32, 0xd3, 0x95, 0x66, 0x80, 0xba, 0xf0}
+
+	configTestcases = [...]QuicLB.Config{
+		// Plaintext.
+		{ConfigID: 0, ServerIDLength: 1, NonceLength: 8, LengthSelfDescription: true},
+		{ConfigID: 1, ServerIDLength: 15, NonceLength: 4},
+		// Single-pass.
+		{ConfigID: 2, ServerIDLength: 4, NonceLength: 12, Key: key, LengthSelfDescription: true},
+		{ConfigID: 3, ServerIDLength: 8, NonceLength: 8, Key: key},
+		// Four-pass with even and odd plaintext lengths.
+		{ConfigID: 4, ServerIDLength: 3, NonceLength: 4, Key: key},
+		{ConfigID: 5, ServerIDLength: 4, NonceLength: 4, Key: key, LengthSelfDescription: true},
+		{ConfigID: 6, ServerIDLength: 10, NonceLength: 9, Key: key},
+	}
+)
+
+func serverID(config QuicLB.Config, id byte) []byte {
+	b := bytes.Repeat([]byte{0xa5}, config.ServerIDLength)
+	b[len(b)-1] = id
+	return b
+}
+
+func TestGenerateAndDecode(t *testing.T) {
+	decoder, err := QuicLB.NewDecoder(configTestcases[:]...)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i, config := range configTestcases {
+		sid := serverID(config, byte(i))
+		generator, err := QuicLB.NewGenerator(config, sid)
+		if err != nil {
+			t.Fatalf("Test %v failed.\n%s", i, err.Error())
+		}
+
+		seen := map[string]bool{}
+		for range 64 {
+			cid, err := generator.GenerateConnectionID()
+			if err != nil {
+				t.Fatalf("Test %v failed.\n%s", i, err.Error())
+			} else if len(cid) != generator.ConnectionIDLength() {
+				t.Fatalf("Test %v failed.\nExpected %v bytes long connection ID but got %v", i, generator.ConnectionIDLength(), len(cid))
+			} else if seen[string(cid)] {
+				t.Fatalf("Test %v failed.\nConnection ID %x is generated twice", i, cid)
+			}
+			seen[string(cid)] = true
+
+			if cid[0]>>5 != config.ConfigID {
+				t.Fatalf("Test %v failed.\nExpected config rotation %v but got %v", i, config.ConfigID, cid[0]>>5)
+			} else if config.LengthSelfDescription && int(cid[0]&0x1f) != len(cid)-1 {
+				t.Fatalf("Test %v failed.\nExpected length %v but got %v", i, len(cid)-1, cid[0]&0x1f)
+			} else if config.Key != nil && bytes.Contains(cid, sid) {
+				t.Fatalf("Test %v failed.\nServer ID %x is not encrypted in %x", i, sid, cid)
+			}
+
+			got, err := decoder.ServerID(cid)
+			if err != nil {
+				t.Fatalf("Test %v failed.\n%s", i, err.Error())
+			} else if !bytes.Equal(got, sid) {
+				t.Fatalf("Test %v failed.\nExpected server ID %x but got %x", i, sid, got)
+			}
+		}
+	}
+}
+
+// Test vectors from draft-ietf-quic-load-balancers, Appendix B.
+var knownAnswerTestcases = [...]struct {
+	ConfigID        uint8
+	Key             string
+	ServerID, Nonce string
+	ConnectionID    string
+}{
+	// Plaintext.
+	{ConfigID: 0, ServerID: "c4605e", Nonce: "4504cc4f", ConnectionID: "07c4605e4504cc4f"},
+	// Four-pass, odd length.
+	{ConfigID: 0, Key: "8f95f09245765f80256934e50c66207f", ServerID: "ed793a", Nonce: "ee080dbf", ConnectionID: "0720b1d07b359d3c"},
+	// Four-pass, odd length.
+	{ConfigID: 1, Key: "8f95f09245765f80256934e50c66207f", ServerID: "ed793a51d49b8f5fab65", Nonce: "ee080dbf48", ConnectionID: "2fcc381bc74cb4fbad2823a3d1f8fed2"},
+	// Single-pass.
+	{ConfigID: 2, Key: "8f95f09245765f80256934e50c66207f", ServerID: "ed793a51d49b8f5f", Nonce: "ee080dbf48c0d1e5", ConnectionID: "504dd2d05a7b0de9b2b9907afb5ecf8cc3"},
+	// Four-pass, even length.
+	{ConfigID: 0, Key: "8f95f09245765f80256934e50c66207f", ServerID: "ed793a51d49b8f5fab", Nonce: "ee080dbf48c0d1e55d", ConnectionID: "125779c9cc86beb3a3a4a3ca96fce4bfe0cdbc"},
+}
+
+func TestKnownAnswer(t *testing.T) {
+	for i, testcase := range knownAnswerTestcases {
+		serverID, nonce := Testing.Unhex(testcase.ServerID), Testing.Unhex(testcase.Nonce)
+		config := QuicLB.Config{
+			ConfigID:              testcase.ConfigID,
+			ServerIDLength:        len(serverID),
+			NonceLength:           len(nonce),
+			LengthSelfDescription: true,
+		}
+		if testcase.Key != "" {
+			config.Key = Testing.Unhex(testcase.Key)
+		}
+
+		cid, err := QuicLB.EncodeConnectionID(config, serverID, nonce)
+		if err != nil {
+			t.Fatalf("Test %v failed.\n%s", i, err.Error())
+		} else if expected := Testing.Unhex(testcase.ConnectionID); !bytes.Equal(cid, expected) {
+			t.Fatalf("Test %v failed.\nExpected %x but got %x", i, expected, cid)
+		}
+
+		decoder, err := QuicLB.NewDecoder(config)
+		if err != nil {
+			t.Fatalf("Test %v failed.\n%s", i, err.Error())
+		}
+		got, err := decoder.ServerID(cid)
+		if err != nil {
+			t.Fatalf("Test %v failed.\n%s", i, err.Error())
+		} else if !bytes.Equal(got, serverID) {
+			t.Fatalf("Test %v failed.\nExpected server ID %x but got %x", i, serverID, got)
+		}
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	testcases := [...]QuicLB.Config{
+		{ConfigID: 7, ServerIDLength: 1, NonceLength: 4},
+		{ConfigID: 0, ServerIDLength: 0, NonceLength: 4},
+		{ConfigID: 0, ServerIDLength: 1, NonceLength: 3},
+		{ConfigID: 0, ServerIDLength: 15, NonceLength: 5},
+		{ConfigID: 0, ServerIDLength: 1, NonceLength: 4, Key: key[:8]},
+	}
+	for i, config := range testcases {
+		if _, err := QuicLB.NewGenerator(config, make([]byte, config.ServerIDLength)); err != QuicLB.InvalidConfig {
+			t.Fatalf("Test %v failed.\nExpected %v but got %v", i, QuicLB.InvalidConfig, err)
+		}
+	}
+
+	if _, err := QuicLB.NewGenerator(configTestcases[0], []byte{1, 2}); err != QuicLB.InvalidServerID {
+		t.Fatal("Expected", QuicLB.InvalidServerID, "but got", err)
+	}
+	if _, err := QuicLB.NewDecoder(configTestcases[0], configTestcases[0]); err != QuicLB.InvalidConfig {
+		t.Fatal("Expected", QuicLB.InvalidConfig, "but got", err)
+	}
+}
+
+func TestUnroutableConnectionID(t *testing.T) {
+	decoder, err := QuicLB.NewDecoder(configTestcases[4])
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	testcases := [...]struct {
+		ConnectionID ConnectionIdentifier.ConnectionID
+		Err          error
+	}{
+		{ConnectionIdentifier.ConnectionID{}, QuicLB.ConnectionIDShort},
+		{ConnectionIdentifier.ConnectionID{0xe0, 1, 2, 3, 4, 5, 6, 7}, QuicLB.UnroutableCID},
+		{ConnectionIdentifier.ConnectionID{0x00, 1, 2, 3, 4, 5, 6, 7}, QuicLB.UnknownConfigID},
+		{ConnectionIdentifier.ConnectionID{0x80, 1, 2, 3}, QuicLB.ConnectionIDShort},
+	}
+	for i, testcase := range testcases {
+		if _, err := decoder.ServerID(testcase.ConnectionID); err != testcase.Err {
+			t.Fatalf("Test %v failed.\nExpected %v but got %v", i, testcase.Err, err)
+		}
+	}
+}
+
+// TestRouting routes short header packets of connections on several servers by the connection IDs issued by each server.
+func TestRouting(t *testing.T) {
+	config := configTestcases[5]
+	decoder, err := QuicLB.NewDecoder(config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	const servers = 4
+	managers := make([]*ConnectionIDManager.Manager, servers)
+	for i := range servers {
+		generator, err := QuicLB.NewGenerator(config, serverID(config, byte(i)))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		localCID, err := generator.GenerateConnectionID()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		managers[i] = ConnectionIDManager.NewManager(generator, localCID, ConnectionIdentifier.ConnectionID{0x01}, 8, make([]byte, 32))
+		if err := managers[i].SetPeerActiveConnectionIDLimit(4); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	parser := Packet.NewShortHeaderParser(Packet.FixedConnectionIDLength(config.ConnectionIDLength()))
+	for i, manager := range managers {
+		for _, local := range manager.LocalConnectionIDs() {
+			packet := append([]byte{0x40}, local.ConnectionID...)
+			packet = append(packet, 0x00, 0x00, 0x00, 0x00)
+
+			header, err := parser.Parse(packet)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			sid, err := decoder.ServerID(header.DestinationConnectionID)
+			if err != nil {
+				t.Fatal(err.Error())
+			} else if int(sid[len(sid)-1]) != i {
+				t.Fatalf("Connection ID %x of server %v is routed to server %v", local.ConnectionID, i, sid[len(sid)-1])
+			}
+		}
+	}
+}
@@ -49,3 +49,7 @@ func (si *StreamID) StreamType() StreamType {
 func (si *StreamID) ToVariableLength() ([]byte, error) {
 	return varint.Int62ToVarint(si.streamID)
 }
+
+func (si *StreamID) ToInt62() varint.Int62 {
+	return si.streamID
+}
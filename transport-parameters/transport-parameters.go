@@ -0,0 +1,455 @@
+package TransportParameters
+
+import (
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"time"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	Datagram "github.com/udan-jayanith/Quick/datagram"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Frame "github.com/udan-jayanith/Quick/frames"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+// Transport Parameter IDs.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-18.2
+const (
+	OriginalDestinationConnectionIDParameterID varint.Int62 = 0x00 + iota
+	MaxIdleTimeoutParameterID
+	StatelessResetTokenParameterID
+	MaxUDPPayloadSizeParameterID
+	InitialMaxDataParameterID
+	InitialMaxStreamDataBidiLocalParameterID
+	InitialMaxStreamDataBidiRemoteParameterID
+	InitialMaxStreamDataUniParameterID
+	InitialMaxStreamsBidiParameterID
+	InitialMaxStreamsUniParameterID
+	AckDelayExponentParameterID
+	MaxAckDelayParameterID
+	DisableActiveMigrationParameterID
+	PreferredAddressParameterID
+	ActiveConnectionIDLimitParameterID
+	InitialSourceConnectionIDParameterID
+	RetrySourceConnectionIDParameterID
+)
+
+const (
+	DefaultMaxUDPPayloadSize varint.Int62 = 65527
+	// Values below 1200 are invalid.
+	MinUDPPayloadSize       varint.Int62  = 1200
+	DefaultAckDelayExponent varint.Int62  = 3
+	MaxAckDelayExponent     varint.Int62  = 20
+	DefaultMaxAckDelay      time.Duration = 25 * time.Millisecond
+	// Values of 2^14 milliseconds or greater are invalid.
+	MaxAckDelayLimit               time.Duration = (1<<14 - 1) * time.Millisecond
+	DefaultActiveConnectionIDLimit varint.Int62  = 2
+)
+
+// PreferredAddress is the address of the server that the client migrates to at the end of the handshake.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-9.6
+type PreferredAddress struct {
+	// The zero value of IPv4 and IPv6 means the server doesn't have a preferred address of the address family.
+	IPv4                netip.AddrPort
+	IPv6                netip.AddrPort
+	ConnectionID        ConnectionIdentifier.ConnectionID
+	StatelessResetToken ConnectionIdentifier.StatelessResetToken
+}
+
+// TransportParameters are the transport parameters of a endpoint carried in the quic_transport_parameters TLS extension.
+// Connection ID parameters that are nil are absent. A empty non nil connection ID is a zero-length connection ID.
+//
+// https://datatracker.ietf.org/doc/html/rfc9000#section-18.2
+type TransportParameters struct {
+	// Only a server sends the original_destination_connection_id.
+	OriginalDestinationConnectionID ConnectionIdentifier.ConnectionID
+	// The idle timeout is disabled when it is 0. It is encoded in milliseconds.
+	MaxIdleTimeout time.Duration
+	// Only a server sends the stateless_reset_token.
+	StatelessResetToken *ConnectionIdentifier.StatelessResetToken
+	MaxUDPPayloadSize   varint.Int62
+
+	InitialMaxData                 varint.Int62
+	InitialMaxStreamDataBidiLocal  varint.Int62
+	InitialMaxStreamDataBidiRemote varint.Int62
+	InitialMaxStreamDataUni        varint.Int62
+	InitialMaxStreamsBidi          varint.Int62
+	InitialMaxStreamsUni           varint.Int62
+
+	AckDelayExponent varint.Int62
+	// MaxAckDelay is encoded in milliseconds.
+	MaxAckDelay time.Duration
+
+	DisableActiveMigration bool
+	// Only a server sends the preferred_address.
+	PreferredAddress *PreferredAddress
+
+	ActiveConnectionIDLimit   varint.Int62
+	InitialSourceConnectionID ConnectionIdentifier.ConnectionID
+	// Only a server sends the retry_source_connection_id.
+	RetrySourceConnectionID ConnectionIdentifier.ConnectionID
+
+	// MaxDatagramFrameSize is 0 if the endpoint doesn't support DATAGRAM frames.
+	//
+	// https://datatracker.ietf.org/doc/html/rfc9221#section-3
+	MaxDatagramFrameSize varint.Int62
+}
+
+// DefaultTransportParameters returns TransportParameters with the default values of absent transport parameters.
+func DefaultTransportParameters() TransportParameters {
+	return TransportParameters{
+		MaxUDPPayloadSize:       DefaultMaxUDPPayloadSize,
+		AckDelayExponent:        DefaultAckDelayExponent,
+		MaxAckDelay:             DefaultMaxAckDelay,
+		ActiveConnectionIDLimit: DefaultActiveConnectionIDLimit,
+	}
+}
+
+func appendVarint(b []byte, v varint.Int62) ([]byte, error) {
+	encoded, err := varint.Int62ToVarint(v)
+	if err != nil {
+		return b, err
+	}
+	return append(b, encoded...), nil
+}
+
+// appendParameter appends a transport parameter with the value.
+func appendParameter(b []byte, id varint.Int62, value []byte) ([]byte, error) {
+	buf, err := appendVarint(b, id)
+	if err != nil {
+		return b, err
+	}
+	buf, err = appendVarint(buf, varint.Int62(len(value)))
+	if err != nil {
+		return b, err
+	}
+	return append(buf, value...), nil
+}
+
+// appendIntegerParameter appends a transport parameter with a integer value, unless the value is the default value.
+func appendIntegerParameter(b []byte, id, v, defaultValue varint.Int62) ([]byte, error) {
+	if v == defaultValue {
+		return b, nil
+	}
+	value, err := varint.Int62ToVarint(v)
+	if err != nil {
+		return b, err
+	}
+	return appendParameter(b, id, value)
+}
+
+// Append appends the encoded transport parameters to b.
+// Integer transport parameters with default values are omitted.
+// If a transport parameter is invalid Append returns QuicErr.TRANSPORT_PARAMETER_ERROR, the error the peer would close the connection with.
+func (p *TransportParameters) Append(b []byte) ([]byte, error) {
+	if !p.valid() {
+		return b, QuicErr.TRANSPORT_PARAMETER_ERROR
+	}
+
+	buf := b
+	var err error
+
+	if p.OriginalDestinationConnectionID != nil {
+		if buf, err = appendParameter(buf, OriginalDestinationConnectionIDParameterID, p.OriginalDestinationConnectionID); err != nil {
+			return b, err
+		}
+	}
+	if p.StatelessResetToken != nil {
+		if buf, err = appendParameter(buf, StatelessResetTokenParameterID, p.StatelessResetToken[:]); err != nil {
+			return b, err
+		}
+	}
+
+	integers := [...]struct {
+		id, v, defaultValue varint.Int62
+	}{
+		{MaxIdleTimeoutParameterID, varint.Int62(p.MaxIdleTimeout.Milliseconds()), 0},
+		{MaxUDPPayloadSizeParameterID, p.MaxUDPPayloadSize, DefaultMaxUDPPayloadSize},
+		{InitialMaxDataParameterID, p.InitialMaxData, 0},
+		{InitialMaxStreamDataBidiLocalParameterID, p.InitialMaxStreamDataBidiLocal, 0},
+		{InitialMaxStreamDataBidiRemoteParameterID, p.InitialMaxStreamDataBidiRemote, 0},
+		{InitialMaxStreamDataUniParameterID, p.InitialMaxStreamDataUni, 0},
+		{InitialMaxStreamsBidiParameterID, p.InitialMaxStreamsBidi, 0},
+		{InitialMaxStreamsUniParameterID, p.InitialMaxStreamsUni, 0},
+		{AckDelayExponentParameterID, p.AckDelayExponent, DefaultAckDelayExponent},
+		{MaxAckDelayParameterID, varint.Int62(p.MaxAckDelay.Milliseconds()), varint.Int62(DefaultMaxAckDelay.Milliseconds())},
+		{ActiveConnectionIDLimitParameterID, p.ActiveConnectionIDLimit, DefaultActiveConnectionIDLimit},
+		{Datagram.MaxDatagramFrameSizeParameterID, p.MaxDatagramFrameSize, 0},
+	}
+	for _, integer := range integers {
+		if buf, err = appendIntegerParameter(buf, integer.id, integer.v, integer.defaultValue); err != nil {
+			return b, err
+		}
+	}
+
+	if p.DisableActiveMigration {
+		if buf, err = appendParameter(buf, DisableActiveMigrationParameterID, nil); err != nil {
+			return b, err
+		}
+	}
+	if p.PreferredAddress != nil {
+		if buf, err = appendParameter(buf, PreferredAddressParameterID, p.PreferredAddress.encode()); err != nil {
+			return b, err
+		}
+	}
+	if p.InitialSourceConnectionID != nil {
+		if buf, err = appendParameter(buf, InitialSourceConnectionIDParameterID, p.InitialSourceConnectionID); err != nil {
+			return b, err
+		}
+	}
+	if p.RetrySourceConnectionID != nil {
+		if buf, err = appendParameter(buf, RetrySourceConnectionIDParameterID, p.RetrySourceConnectionID); err != nil {
+			return b, err
+		}
+	}
+	return buf, nil
+}
+
+// valid reports whether ParseTransportParameters accepts the encoded transport parameters.
+func (p *TransportParameters) valid() bool {
+	if p.MaxIdleTimeout < 0 || p.MaxAckDelay < 0 {
+		return false
+	}
+	integers := [...]struct {
+		id, v varint.Int62
+	}{
+		{MaxIdleTimeoutParameterID, varint.Int62(p.MaxIdleTimeout.Milliseconds())},
+		{MaxUDPPayloadSizeParameterID, p.MaxUDPPayloadSize},
+		{InitialMaxStreamsBidiParameterID, p.InitialMaxStreamsBidi},
+		{InitialMaxStreamsUniParameterID, p.InitialMaxStreamsUni},
+		{AckDelayExponentParameterID, p.AckDelayExponent},
+		{MaxAckDelayParameterID, varint.Int62(p.MaxAckDelay.Milliseconds())},
+		{ActiveConnectionIDLimitParameterID, p.ActiveConnectionIDLimit},
+	}
+	for _, integer := range integers {
+		if !validInteger(integer.id, integer.v) {
+			return false
+		}
+	}
+
+	for _, cid := range [...]ConnectionIdentifier.ConnectionID{p.OriginalDestinationConnectionID, p.InitialSourceConnectionID, p.RetrySourceConnectionID} {
+		if len(cid) > ConnectionIdentifier.MaxConnectionIDLength {
+			return false
+		}
+	}
+	// A server that chooses a zero-length connection ID MUST NOT provide a preferred address.
+	if pa := p.PreferredAddress; pa != nil && (len(pa.ConnectionID) == 0 || len(pa.ConnectionID) > ConnectionIdentifier.MaxConnectionIDLength) {
+		return false
+	}
+	return true
+}
+
+/*
+Preferred Address {
+  IPv4 Address (32),
+  IPv4 Port (16),
+  IPv6 Address (128),
+  IPv6 Port (16),
+  Connection ID Length (8),
+  Connection ID (..),
+  Stateless Reset Token (128),
+}
+*/
+
+func (pa *PreferredAddress) encode() []byte {
+	b := make([]byte, 0, 4+2+16+2+1+len(pa.ConnectionID)+ConnectionIdentifier.StatelessResetTokenLength)
+	ipv4 := [4]byte{}
+	if pa.IPv4.Addr().Is4() {
+		ipv4 = pa.IPv4.Addr().As4()
+	}
+	b = append(b, ipv4[:]...)
+	b = binary.BigEndian.AppendUint16(b, pa.IPv4.Port())
+	ipv6 := [16]byte{}
+	if pa.IPv6.Addr().Is6() {
+		ipv6 = pa.IPv6.Addr().As16()
+	}
+	b = append(b, ipv6[:]...)
+	b = binary.BigEndian.AppendUint16(b, pa.IPv6.Port())
+	b = append(b, byte(len(pa.ConnectionID)))
+	b = append(b, pa.ConnectionID...)
+	return append(b, pa.StatelessResetToken[:]...)
+}
+
+func decodePreferredAddress(b []byte) (*PreferredAddress, bool) {
+	if len(b) < 4+2+16+2+1 {
+		return nil, false
+	}
+	pa := &PreferredAddress{}
+	if ipv4 := netip.AddrFrom4([4]byte(b[:4])); !ipv4.IsUnspecified() || binary.BigEndian.Uint16(b[4:6]) != 0 {
+		pa.IPv4 = netip.AddrPortFrom(ipv4, binary.BigEndian.Uint16(b[4:6]))
+	}
+	if ipv6 := netip.AddrFrom16([16]byte(b[6:22])); !ipv6.IsUnspecified() || binary.BigEndian.Uint16(b[22:24]) != 0 {
+		pa.IPv6 = netip.AddrPortFrom(ipv6, binary.BigEndian.Uint16(b[22:24]))
+	}
+
+	// A server that chooses a zero-length connection ID MUST NOT provide a preferred address.
+	length := int(b[24])
+	b = b[25:]
+	if length == 0 || length > ConnectionIdentifier.MaxConnectionIDLength || len(b) != length+ConnectionIdentifier.StatelessResetTokenLength {
+		return nil, false
+	}
+	pa.ConnectionID = ConnectionIdentifier.ConnectionID(append([]byte{}, b[:length]...))
+	pa.StatelessResetToken = ConnectionIdentifier.StatelessResetToken(b[length:])
+	return pa, true
+}
+
+// readVarint decodes the variable length integer at the start of b and returns the value and it's length in bytes.
+func readVarint(b []byte) (varint.Int62, int, bool) {
+	if len(b) == 0 {
+		return 0, 0, false
+	}
+	length := 1 << (b[0] >> 6)
+	if len(b) < length {
+		return 0, 0, false
+	}
+
+	// VarintToInt62 modifies the slice.
+	v, err := varint.VarintToInt62(append([]byte(nil), b[:length]...))
+	return v, length, err == nil
+}
+
+// ParseTransportParameters decodes the transport parameters sent by a endpoint of the sender role.
+// Absent transport parameters have the default values. Transport parameters with unknown IDs, which include the reserved
+// transport parameters of the form 31 * N + 27, are ignored.
+//
+// If the transport parameters are badly formatted, duplicated, invalid or not allowed for the sender ParseTransportParameters returns QuicErr.TRANSPORT_PARAMETER_ERROR.
+func ParseTransportParameters(b []byte, sender Packet.Role) (TransportParameters, QuicErr.Err) {
+	p := DefaultTransportParameters()
+	seen := map[varint.Int62]bool{}
+
+	for len(b) > 0 {
+		id, n, ok := readVarint(b)
+		if !ok {
+			return p, QuicErr.TRANSPORT_PARAMETER_ERROR
+		}
+		b = b[n:]
+		length, n, ok := readVarint(b)
+		if !ok || varint.Int62(len(b)-n) < length {
+			return p, QuicErr.TRANSPORT_PARAMETER_ERROR
+		}
+		value := b[n : n+int(length)]
+		b = b[n+int(length):]
+
+		if seen[id] {
+			return p, QuicErr.TRANSPORT_PARAMETER_ERROR
+		}
+		seen[id] = true
+
+		if !p.decodeParameter(id, value, sender) {
+			return p, QuicErr.TRANSPORT_PARAMETER_ERROR
+		}
+	}
+	return p, QuicErr.NO_ERROR
+}
+
+// decodeParameter decodes a transport parameter and reports whether it's valid.
+func (p *TransportParameters) decodeParameter(id varint.Int62, value []byte, sender Packet.Role) bool {
+	switch id {
+	case OriginalDestinationConnectionIDParameterID, StatelessResetTokenParameterID, PreferredAddressParameterID, RetrySourceConnectionIDParameterID:
+		// A client MUST NOT include any server-only transport parameter.
+		if sender == Packet.Client {
+			return false
+		}
+	}
+
+	switch id {
+	case OriginalDestinationConnectionIDParameterID:
+		return decodeConnectionID(value, &p.OriginalDestinationConnectionID)
+	case InitialSourceConnectionIDParameterID:
+		return decodeConnectionID(value, &p.InitialSourceConnectionID)
+	case RetrySourceConnectionIDParameterID:
+		return decodeConnectionID(value, &p.RetrySourceConnectionID)
+	case StatelessResetTokenParameterID:
+		if len(value) != ConnectionIdentifier.StatelessResetTokenLength {
+			return false
+		}
+		token := ConnectionIdentifier.StatelessResetToken(value)
+		p.StatelessResetToken = &token
+		return true
+	case DisableActiveMigrationParameterID:
+		p.DisableActiveMigration = true
+		return len(value) == 0
+	case PreferredAddressParameterID:
+		pa, ok := decodePreferredAddress(value)
+		p.PreferredAddress = pa
+		return ok
+	}
+
+	var target *varint.Int62
+	switch id {
+	case MaxUDPPayloadSizeParameterID:
+		target = &p.MaxUDPPayloadSize
+	case InitialMaxDataParameterID:
+		target = &p.InitialMaxData
+	case InitialMaxStreamDataBidiLocalParameterID:
+		target = &p.InitialMaxStreamDataBidiLocal
+	case InitialMaxStreamDataBidiRemoteParameterID:
+		target = &p.InitialMaxStreamDataBidiRemote
+	case InitialMaxStreamDataUniParameterID:
+		target = &p.InitialMaxStreamDataUni
+	case InitialMaxStreamsBidiParameterID:
+		target = &p.InitialMaxStreamsBidi
+	case InitialMaxStreamsUniParameterID:
+		target = &p.InitialMaxStreamsUni
+	case AckDelayExponentParameterID:
+		target = &p.AckDelayExponent
+	case ActiveConnectionIDLimitParameterID:
+		target = &p.ActiveConnectionIDLimit
+	case Datagram.MaxDatagramFrameSizeParameterID:
+		target = &p.MaxDatagramFrameSize
+	case MaxIdleTimeoutParameterID, MaxAckDelayParameterID:
+		target = new(varint.Int62)
+	default:
+		// Unknown transport parameters are ignored.
+		return true
+	}
+
+	v, n, ok := readVarint(value)
+	if !ok || n != len(value) {
+		return false
+	}
+	*target = v
+	if !validInteger(id, v) {
+		return false
+	}
+
+	switch id {
+	case MaxIdleTimeoutParameterID:
+		p.MaxIdleTimeout = time.Duration(v) * time.Millisecond
+	case MaxAckDelayParameterID:
+		p.MaxAckDelay = time.Duration(v) * time.Millisecond
+	}
+	return true
+}
+
+// validInteger reports whether v is a valid value of the integer transport parameter.
+func validInteger(id, v varint.Int62) bool {
+	switch id {
+	case MaxIdleTimeoutParameterID:
+		// Idle timeouts that overflow time.Duration are invalid.
+		return v <= varint.Int62(math.MaxInt64/time.Millisecond)
+	case MaxAckDelayParameterID:
+		return v <= varint.Int62(MaxAckDelayLimit/time.Millisecond)
+	case MaxUDPPayloadSizeParameterID:
+		return v >= MinUDPPayloadSize
+	case InitialMaxStreamsBidiParameterID, InitialMaxStreamsUniParameterID:
+		return v <= Frame.MaxStreamsLimit
+	case AckDelayExponentParameterID:
+		return v <= MaxAckDelayExponent
+	case ActiveConnectionIDLimitParameterID:
+		return v >= DefaultActiveConnectionIDLimit
+	}
+	return true
+}
+
+func decodeConnectionID(value []byte, cid *ConnectionIdentifier.ConnectionID) bool {
+	if len(value) > ConnectionIdentifier.MaxConnectionIDLength {
+		return false
+	}
+	*cid = ConnectionIdentifier.ConnectionID(append([]byte{}, value...))
+	return true
+}
@@ -0,0 +1,151 @@
+package TransportParameters_test
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	ConnectionIdentifier "github.com/udan-jayanith/Quick/connection-identifier"
+	QuicErr "github.com/udan-jayanith/Quick/errors"
+	Testing "github.com/udan-jayanith/Quick/internal/testing"
+	Packet "github.com/udan-jayanith/Quick/packet"
+	TransportParameters "github.com/udan-jayanith/Quick/transport-parameters"
+	"github.com/udan-jayanith/Quick/varint"
+)
+
+func serverTransportParameters() TransportParameters.TransportParameters {
+	token := ConnectionIdentifier.StatelessResetToken{0x01, 0x02, 0x03}
+	p := TransportParameters.DefaultTransportParameters()
+	p.OriginalDestinationConnectionID = ConnectionIdentifier.ConnectionID{0x83, 0x94, 0xc8, 0xf0}
+	p.MaxIdleTimeout = 30 * time.Second
+	p.StatelessResetToken = &token
+	p.MaxUDPPayloadSize = 1472
+	p.InitialMaxData = 1 << 20
+	p.InitialMaxStreamDataBidiLocal = 1 << 16
+	p.InitialMaxStreamDataBidiRemote = 1 << 17
+	p.InitialMaxStreamDataUni = 1 << 18
+	p.InitialMaxStreamsBidi = 100
+	p.InitialMaxStreamsUni = 3
+	p.AckDelayExponent = 8
+	p.MaxAckDelay = 50 * time.Millisecond
+	p.DisableActiveMigration = true
+	p.PreferredAddress = &TransportParameters.PreferredAddress{
+		IPv4:                netip.MustParseAddrPort("192.0.2.1:443"),
+		IPv6:                netip.MustParseAddrPort("[2001:db8::1]:4433"),
+		ConnectionID:        ConnectionIdentifier.ConnectionID{0x0a, 0x0b, 0x0c, 0x0d},
+		StatelessResetToken: ConnectionIdentifier.StatelessResetToken{0x04},
+	}
+	p.ActiveConnectionIDLimit = 4
+	// A zero-length connection ID.
+	p.InitialSourceConnectionID = ConnectionIdentifier.ConnectionID{}
+	p.RetrySourceConnectionID = ConnectionIdentifier.ConnectionID{0xf0, 0x67}
+	p.MaxDatagramFrameSize = 65535
+	return p
+}
+
+func TestTransportParameters(t *testing.T) {
+	for _, p := range [...]TransportParameters.TransportParameters{serverTransportParameters(), TransportParameters.DefaultTransportParameters()} {
+		b, err := p.Append(nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		decoded, qerr := TransportParameters.ParseTransportParameters(b, Packet.Server)
+		if qerr != QuicErr.NO_ERROR {
+			t.Fatal(qerr.Error())
+		} else if !reflect.DeepEqual(decoded, p) {
+			t.Fatalf("Test failed.\nExpected %s\nGot %s", Testing.ToFormattedJson(p), Testing.ToFormattedJson(decoded))
+		}
+	}
+
+	// Default values are not encoded.
+	p := TransportParameters.DefaultTransportParameters()
+	if b, err := p.Append(nil); err != nil || len(b) != 0 {
+		t.Fatal("Expected default transport parameters to be empty but got", b, err)
+	}
+}
+
+func TestUnknownTransportParameters(t *testing.T) {
+	p := TransportParameters.DefaultTransportParameters()
+	p.InitialMaxData = 1000
+	b, err := p.Append(nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Reserved transport parameters of the form 31 * N + 27 and unknown transport parameters are skipped.
+	for _, id := range [...]varint.Int62{31*2 + 27, 0x3fff} {
+		b = append(b, Testing.Int62ToVarint(id)...)
+		b = append(b, 0x03, 0xaa, 0xbb, 0xcc)
+	}
+
+	decoded, qerr := TransportParameters.ParseTransportParameters(b, Packet.Client)
+	if qerr != QuicErr.NO_ERROR {
+		t.Fatal(qerr.Error())
+	} else if !reflect.DeepEqual(decoded, p) {
+		t.Fatalf("Test failed.\nExpected %s\nGot %s", Testing.ToFormattedJson(p), Testing.ToFormattedJson(decoded))
+	}
+}
+
+var (
+	invalidTransportParametersTestcases = [...]struct {
+		Name   string
+		Sender Packet.Role
+		Params []byte
+	}{
+		{Name: "Truncated value", Sender: Packet.Server, Params: []byte{0x04, 0x02, 0x01}},
+		{Name: "Truncated length", Sender: Packet.Server, Params: []byte{0x04}},
+		{Name: "Duplicate", Sender: Packet.Server, Params: []byte{0x04, 0x01, 0x01, 0x04, 0x01, 0x02}},
+		{Name: "Trailing bytes in integer", Sender: Packet.Server, Params: []byte{0x04, 0x02, 0x01, 0x02}},
+		{Name: "Server only parameter sent by client", Sender: Packet.Client, Params: []byte{0x00, 0x01, 0x01}},
+		{Name: "Stateless reset token length", Sender: Packet.Server, Params: []byte{0x02, 0x01, 0x01}},
+		{Name: "max_udp_payload_size below 1200", Sender: Packet.Server, Params: []byte{0x03, 0x02, 0x44, 0xaf}},
+		{Name: "initial_max_streams_bidi above 2^60", Sender: Packet.Server, Params: []byte{0x08, 0x08, 0xd0, 0, 0, 0, 0, 0, 0, 0x01}},
+		{Name: "ack_delay_exponent above 20", Sender: Packet.Server, Params: []byte{0x0a, 0x01, 21}},
+		{Name: "max_ack_delay of 2^14", Sender: Packet.Server, Params: []byte{0x0b, 0x04, 0x80, 0x00, 0x40, 0x00}},
+		{Name: "active_connection_id_limit below 2", Sender: Packet.Server, Params: []byte{0x0e, 0x01, 0x01}},
+		{Name: "disable_active_migration with a value", Sender: Packet.Server, Params: []byte{0x0c, 0x01, 0x01}},
+		{Name: "Connection ID too long", Sender: Packet.Server, Params: append([]byte{0x0f, 21}, make([]byte, 21)...)},
+		{Name: "preferred_address with a zero-length connection ID", Sender: Packet.Server, Params: append([]byte{0x0d, 41}, make([]byte, 41)...)},
+	}
+)
+
+func TestInvalidTransportParameters(t *testing.T) {
+	for _, testcase := range invalidTransportParametersTestcases {
+		if _, qerr := TransportParameters.ParseTransportParameters(testcase.Params, testcase.Sender); qerr != QuicErr.TRANSPORT_PARAMETER_ERROR {
+			t.Fatalf("Test %v failed.\nExpected %v\nGot %v", testcase.Name, QuicErr.TRANSPORT_PARAMETER_ERROR, qerr)
+		}
+	}
+}
+
+func TestAppendInvalidTransportParameters(t *testing.T) {
+	testcases := [...]struct {
+		Name   string
+		Modify func(p *TransportParameters.TransportParameters)
+	}{
+		{"max_udp_payload_size below 1200", func(p *TransportParameters.TransportParameters) { p.MaxUDPPayloadSize = 1199 }},
+		{"ack_delay_exponent above 20", func(p *TransportParameters.TransportParameters) { p.AckDelayExponent = 21 }},
+		{"max_ack_delay of 2^14", func(p *TransportParameters.TransportParameters) { p.MaxAckDelay = 1 << 14 * time.Millisecond }},
+		{"negative max_ack_delay", func(p *TransportParameters.TransportParameters) { p.MaxAckDelay = -time.Millisecond }},
+		{"negative max_idle_timeout", func(p *TransportParameters.TransportParameters) { p.MaxIdleTimeout = -time.Second }},
+		{"active_connection_id_limit below 2", func(p *TransportParameters.TransportParameters) { p.ActiveConnectionIDLimit = 1 }},
+		{"initial_max_streams_bidi above 2^60", func(p *TransportParameters.TransportParameters) { p.InitialMaxStreamsBidi = 1<<60 + 1 }},
+		{"long connection ID", func(p *TransportParameters.TransportParameters) {
+			p.InitialSourceConnectionID = make(ConnectionIdentifier.ConnectionID, 21)
+		}},
+		{"zero-length preferred address connection ID", func(p *TransportParameters.TransportParameters) {
+			p.PreferredAddress.ConnectionID = ConnectionIdentifier.ConnectionID{}
+		}},
+	}
+
+	for _, testcase := range testcases {
+		p := serverTransportParameters()
+		testcase.Modify(&p)
+		if b, err := p.Append(nil); err != QuicErr.TRANSPORT_PARAMETER_ERROR {
+			t.Fatalf("Test %v failed.\nExpected %v\nGot %v", testcase.Name, QuicErr.TRANSPORT_PARAMETER_ERROR, err)
+		} else if len(b) != 0 {
+			t.Fatalf("Test %v failed.\nExpected nothing appended but got %x", testcase.Name, b)
+		}
+	}
+}
@@ -61,6 +61,21 @@ func Int62ToVarint(v Int62) ([]byte, error) {
 	return buf, nil
 }
 
+// VarintLength returns the number of bytes Int62ToVarint uses to encode v.
+// VarintLength returns 0 if v is overflowing.
+func VarintLength(v Int62) int {
+	if v <= 63 {
+		return 1
+	} else if v <= 16383 {
+		return 2
+	} else if v <= 1073741823 {
+		return 4
+	} else if v <= 4611686018427387903 {
+		return 8
+	}
+	return 0
+}
+
 // This is actually int62
 func VarintToInt62(b []byte) (Int62, error) {
 	if len(b) == 0 || len(b) > 8 {
@@ -69,3 +69,20 @@ func TestReadVarint62(t *testing.T) {
 		}
 	}
 }
+
+func TestVarintLength(t *testing.T) {
+	for _, num := range [...]varint.Int62{0, 60, 63, 64, 16383, 16384, 1073741823, 1073741824, 4611686018427387903} {
+		b, err := varint.Int62ToVarint(num)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if l := varint.VarintLength(num); l != len(b) {
+			t.Fatal("Expected", len(b), "but got", l, "for", num)
+		}
+	}
+
+	if l := varint.VarintLength(4611686018427387903 + 1); l != 0 {
+		t.Fatal("Expected 0 but got", l)
+	}
+}
@@ -6,3 +6,18 @@ const (
 	VersionNegotiation QuickVersion = 0 + iota
 	V1
 )
+
+var (
+	// SupportedVersions are the versions supported by Quick in the order of preference.
+	SupportedVersions = [...]QuickVersion{V1}
+)
+
+// IsSupported reports whether the version is supported by Quick.
+func (v QuickVersion) IsSupported() bool {
+	for _, supported := range SupportedVersions {
+		if v == supported {
+			return true
+		}
+	}
+	return false
+}